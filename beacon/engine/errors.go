@@ -40,6 +40,9 @@ func (e *EngineAPIError) ErrorData() interface{} {
 	}{e.err.Error()}
 }
 
+// Unwrap returns the embedded custom error.
+func (e *EngineAPIError) Unwrap() error { return e.err }
+
 // With returns a copy of the error with a new embedded custom data field.
 func (e *EngineAPIError) With(err error) *EngineAPIError {
 	return &EngineAPIError{
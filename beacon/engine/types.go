@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 )
@@ -138,6 +139,13 @@ type PayloadStatusV1 struct {
 	ValidationError *string        `json:"validationError"`
 }
 
+// GoatPayloadStatus is the status of a goat payload, the goat requests diverged
+// from the locally executed ones are reported if the payload is invalid.
+type GoatPayloadStatus struct {
+	PayloadStatusV1
+	RequestsDiff []*goattypes.RequestDiff `json:"requestsDiff,omitempty"`
+}
+
 type TransitionConfigurationV1 struct {
 	TerminalTotalDifficulty *hexutil.Big   `json:"terminalTotalDifficulty"`
 	TerminalBlockHash       common.Hash    `json:"terminalBlockHash"`
@@ -234,7 +242,9 @@ func ExecutableDataToBlockNoHash(data ExecutableData, versionedHashes []common.H
 	if err != nil {
 		return nil, err
 	}
-	if len(data.ExtraData) > int(params.MaximumExtraDataSize) {
+	// the goat header extra could be longer after a goat fork, the header verifier
+	// will check the length against the chain config
+	if len(data.ExtraData) > max(int(params.MaximumExtraDataSize), params.GoatMaxHeaderExtraLength) {
 		return nil, fmt.Errorf("invalid extradata length: %v", len(data.ExtraData))
 	}
 	if len(data.LogsBloom) != 256 {
@@ -0,0 +1,91 @@
+package t8ntool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// goatBlock tracks the goat txs and the gas fees of the block being applied
+type goatBlock struct {
+	params params.GoatParams
+	txs    types.Transactions // included goat txs, they're at the head of the block
+	reward *big.Int           // tips of the included non-goat txs
+}
+
+func newGoatBlock(chainConfig *params.ChainConfig, time uint64) *goatBlock {
+	if chainConfig.Goat == nil {
+		return nil
+	}
+	return &goatBlock{params: chainConfig.Goat.Params(time), reward: new(big.Int)}
+}
+
+// checkTx checks if the tx could be included at the given index of the block
+func (g *goatBlock) checkTx(tx *types.Transaction, txIndex int) error {
+	if g == nil {
+		if tx.IsGoatTx() {
+			return errors.New("goat tx is not supported by the fork")
+		}
+		return nil
+	}
+	if !tx.IsGoatTx() {
+		if tx.Type() == types.BlobTxType {
+			return errors.New("blob transaction is not allowed")
+		}
+		return nil
+	}
+	if txIndex != len(g.txs) {
+		return errors.New("goat tx should be placed before the other transactions")
+	}
+	if err := core.CheckGoatTx(g.params, tx.AsGoatTx()); err != nil {
+		return err
+	}
+	if len(g.txs) >= g.params.TxLimitPerBlock {
+		return fmt.Errorf("too many goat txs: max %d", g.params.TxLimitPerBlock)
+	}
+	return nil
+}
+
+// addTx records the included tx and its tip
+func (g *goatBlock) addTx(tx *types.Transaction, receipt *types.Receipt, baseFee *big.Int) {
+	if g == nil {
+		return
+	}
+	if tx.IsGoatTx() {
+		g.txs = append(g.txs, tx)
+	}
+	if receipt.GasUsed > 0 { // non-goatTx case
+		tipFee := new(big.Int).SetUint64(receipt.GasUsed)
+		tipFee.Mul(tipFee, tx.EffectiveGasTipValue(baseFee))
+		g.reward.Add(g.reward, tipFee)
+	}
+}
+
+// extra returns the header extra committing the goat txs and requests
+func (g *goatBlock) extra(requests [][]byte, statedb *state.StateDB) ([]byte, error) {
+	return core.NewGoatHeaderExtra(g.params, g.txs, requests, statedb)
+}
+
+// requests pays the gas fees to the foundation and the locking contract, and
+// generates the goat requests of the block.
+func (g *goatBlock) requests(statedb *state.StateDB, vmContext vm.BlockContext, gasUsed, blobGasUsed uint64, receipts types.Receipts) ([][]byte, error) {
+	burnt := new(big.Int)
+	if vmContext.BaseFee != nil && gasUsed > 0 {
+		burnt.Mul(vmContext.BaseFee, new(big.Int).SetUint64(gasUsed))
+	}
+	if vmContext.BlobBaseFee != nil && blobGasUsed > 0 {
+		burnt.Add(burnt, new(big.Int).Mul(vmContext.BlobBaseFee, new(big.Int).SetUint64(blobGasUsed)))
+	}
+	var allLogs []*types.Log
+	for _, receipt := range receipts {
+		allLogs = append(allLogs, receipt.Logs...)
+	}
+	record := core.ProcessGoatGasFee(g.params, statedb, burnt, new(big.Int).Set(g.reward))
+	return core.ProcessGoatRequests(vmContext.BlockNumber.Uint64(), record.Revenue, allLogs)
+}
@@ -32,6 +32,7 @@ func (e ExecutionResult) MarshalJSON() ([]byte, error) {
 		CurrentBlobGasUsed   *math.HexOrDecimal64  `json:"blobGasUsed,omitempty"`
 		RequestsHash         *common.Hash          `json:"requestsHash,omitempty"`
 		Requests             []hexutil.Bytes       `json:"requests,omitempty"`
+		ExtraData            hexutil.Bytes         `json:"extraData,omitempty"`
 	}
 	var enc ExecutionResult
 	enc.StateRoot = e.StateRoot
@@ -54,6 +55,7 @@ func (e ExecutionResult) MarshalJSON() ([]byte, error) {
 			enc.Requests[k] = v
 		}
 	}
+	enc.ExtraData = e.ExtraData
 	return json.Marshal(&enc)
 }
 
@@ -75,6 +77,7 @@ func (e *ExecutionResult) UnmarshalJSON(input []byte) error {
 		CurrentBlobGasUsed   *math.HexOrDecimal64  `json:"blobGasUsed,omitempty"`
 		RequestsHash         *common.Hash          `json:"requestsHash,omitempty"`
 		Requests             []hexutil.Bytes       `json:"requests,omitempty"`
+		ExtraData            *hexutil.Bytes        `json:"extraData,omitempty"`
 	}
 	var dec ExecutionResult
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -130,5 +133,8 @@ func (e *ExecutionResult) UnmarshalJSON(input []byte) error {
 			e.Requests[k] = v
 		}
 	}
+	if dec.ExtraData != nil {
+		e.ExtraData = *dec.ExtraData
+	}
 	return nil
 }
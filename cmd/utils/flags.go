@@ -961,7 +961,7 @@ var (
 		HoleskyFlag,
 	}
 	// NetworkFlags is the flag group of all built-in supported networks.
-	NetworkFlags = append([]cli.Flag{MainnetFlag, GoatNetworkFlag}, TestnetFlags...)
+	NetworkFlags = append([]cli.Flag{MainnetFlag, GoatNetworkFlag, GoatGenesisFlag}, TestnetFlags...)
 
 	// DatabaseFlags is the flag group of all database flags.
 	DatabaseFlags = []cli.Flag{
@@ -1045,6 +1045,13 @@ func setBootstrapNodes(ctx *cli.Context, cfg *p2p.Config) {
 			urls = params.HoleskyBootnodes
 		case ctx.Bool(SepoliaFlag.Name):
 			urls = params.SepoliaBootnodes
+		case ctx.IsSet(GoatNetworkFlag.Name):
+			switch ctx.String(GoatNetworkFlag.Name) {
+			case "testnet":
+				urls = params.V5GoatTestnetBootnodes
+			default:
+				urls = nil // no bootnodes for the custom goat network
+			}
 		}
 	}
 	cfg.BootstrapNodes = mustParseBootnodes(urls)
@@ -1079,6 +1086,8 @@ func setBootstrapNodesV5(ctx *cli.Context, cfg *p2p.Config) {
 		switch goatNetwork {
 		case "testnet":
 			urls = params.V5GoatTestnetBootnodes
+		default:
+			urls = nil // no bootnodes for the custom goat network
 		}
 	}
 
@@ -1746,6 +1755,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(CacheLogSizeFlag.Name) {
 		cfg.FilterLogCacheSize = ctx.Int(CacheLogSizeFlag.Name)
 	}
+	if ctx.IsSet(GoatBridgeIndexFlag.Name) {
+		cfg.GoatBridgeIndex = ctx.Bool(GoatBridgeIndexFlag.Name)
+	}
+	if ctx.IsSet(GoatLockingIndexFlag.Name) {
+		cfg.GoatLockingIndex = ctx.Bool(GoatLockingIndexFlag.Name)
+	}
 	if !ctx.Bool(SnapshotFlag.Name) || cfg.SnapshotCache == 0 {
 		// If snap-sync is requested, this flag is also required
 		if cfg.SyncMode == downloader.SnapSync {
@@ -1868,7 +1883,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		log.Info("Using developer account", "address", developer.Address)
 
 		// Create a new developer genesis block or reuse existing one
-		cfg.Genesis = core.DeveloperGenesisBlock(ctx.Uint64(DeveloperGasLimitFlag.Name), &developer.Address)
+		cfg.Genesis = MakeDeveloperGenesis(ctx, ctx.Uint64(DeveloperGasLimitFlag.Name), &developer.Address)
 		if ctx.IsSet(DataDirFlag.Name) {
 			chaindb := tryMakeReadOnlyDatabase(ctx, stack)
 			if rawdb.ReadCanonicalHash(chaindb, 0) != (common.Hash{}) {
@@ -2089,8 +2104,10 @@ func tryMakeReadOnlyDatabase(ctx *cli.Context, stack *node.Node) ethdb.Database
 
 func IsNetworkPreset(ctx *cli.Context) bool {
 	for _, flag := range NetworkFlags {
-		bFlag, _ := flag.(*cli.BoolFlag)
-		if ctx.IsSet(bFlag.Name) {
+		if flag == GoatGenesisFlag {
+			continue // it only selects the genesis of --goat custom
+		}
+		if ctx.IsSet(flag.Names()[0]) {
 			return true
 		}
 	}
@@ -2129,6 +2146,8 @@ func MakeGenesis(ctx *cli.Context) *core.Genesis {
 		switch netwk {
 		case "testnet":
 			genesis = core.DefaultGoatTestnetGenesisBlock()
+		case "custom":
+			genesis = ReadGoatGenesis(ctx.String(GoatGenesisFlag.Name))
 		default:
 			Fatalf("unknown goat network: %s", netwk)
 		}
@@ -1,6 +1,11 @@
 package utils
 
 import (
+	"encoding/json"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/urfave/cli/v2"
 )
@@ -8,7 +13,58 @@ import (
 var (
 	GoatNetworkFlag = &cli.StringFlag{
 		Name:     "goat",
-		Usage:    "Run goat network",
+		Usage:    "Run goat network (testnet, custom)",
+		Category: flags.EthCategory,
+	}
+	GoatGenesisFlag = &cli.StringFlag{
+		Name:     "goat.genesis",
+		Usage:    "Genesis file of the custom goat network (used with --goat custom)",
+		Category: flags.EthCategory,
+	}
+	GoatBridgeIndexFlag = &cli.BoolFlag{
+		Name:     "goat.bridgeindex",
+		Usage:    "Enable indexing the goat bridge withdrawals, deposits and bitcoin blocks (goat_getWithdrawal, goat_getDeposit, goat_btcBlock, goat_btcTip)",
+		Category: flags.EthCategory,
+	}
+	GoatLockingIndexFlag = &cli.BoolFlag{
+		Name:     "goat.lockingindex",
+		Usage:    "Enable indexing the goat locking events of the validators (goat_validator, goat_validators)",
 		Category: flags.EthCategory,
 	}
+	DeveloperGoatFlag = &cli.BoolFlag{
+		Name:     "dev.goat",
+		Usage:    "Start developer mode from a goat genesis, goat txs are queued with the dev RPC namespace",
+		Category: flags.DevCategory,
+	}
 )
+
+// ReadGoatGenesis reads a goat genesis from the file and verifies that it's
+// a valid goat genesis.
+func ReadGoatGenesis(path string) *core.Genesis {
+	if path == "" {
+		Fatalf("--%s is required for the custom goat network", GoatGenesisFlag.Name)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		Fatalf("Failed to read goat genesis file: %v", err)
+	}
+	defer file.Close()
+
+	genesis := new(core.Genesis)
+	if err := json.NewDecoder(file).Decode(genesis); err != nil {
+		Fatalf("Invalid goat genesis file: %v", err)
+	}
+	if err := genesis.VerifyGoatGenesis(); err != nil {
+		Fatalf("Invalid goat genesis: %v", err)
+	}
+	return genesis
+}
+
+// MakeDeveloperGenesis returns the developer mode genesis, the goat one is used
+// if --dev.goat is set.
+func MakeDeveloperGenesis(ctx *cli.Context, gasLimit uint64, faucet *common.Address) *core.Genesis {
+	if ctx.Bool(DeveloperGoatFlag.Name) {
+		return core.DeveloperGoatGenesisBlock(gasLimit, faucet)
+	}
+	return core.DeveloperGenesisBlock(gasLimit, faucet)
+}
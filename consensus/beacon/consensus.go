@@ -226,9 +226,14 @@ func (beacon *Beacon) VerifyUncles(chain consensus.ChainReader, block *types.Blo
 // (b) we don't verify if a block is in the future anymore
 // (c) the extradata is limited to 32 bytes
 func (beacon *Beacon) verifyHeader(chain consensus.ChainHeaderReader, header, parent *types.Header) error {
-	// Ensure that the header's extra-data section is of a reasonable size
-	if len(header.Extra) > int(params.MaximumExtraDataSize) {
-		return fmt.Errorf("extra-data longer than 32 bytes (%d)", len(header.Extra))
+	// Ensure that the header's extra-data section is of a reasonable size,
+	// the exact length of the goat header extra is checked by the block validator
+	maxExtraDataSize := int(params.MaximumExtraDataSize)
+	if chain.Config().Goat != nil {
+		maxExtraDataSize = params.GoatMaxHeaderExtraLength
+	}
+	if len(header.Extra) > maxExtraDataSize {
+		return fmt.Errorf("extra-data longer than %d bytes (%d)", maxExtraDataSize, len(header.Extra))
 	}
 	// Verify the seal parts. Ensure the nonce and uncle hash are the expected value.
 	if header.Nonce != beaconNonce {
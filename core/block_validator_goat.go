@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 )
@@ -15,12 +17,13 @@ func (v *BlockValidator) validateGoatBlock(block *types.Block) error {
 		return nil
 	}
 
-	extra := block.Header().Extra
-	if len(extra) != params.GoatHeaderExtraLengthV0 {
-		return fmt.Errorf("no goat tx root found (block %x)", block.Number())
+	goat := v.config.Goat.Params(block.Time())
+	extra, err := goattypes.DecodeHeaderExtra(goat, block.Header().Extra)
+	if err != nil {
+		return fmt.Errorf("invalid goat header extra (block %d): %w", block.Number(), err)
 	}
 
-	txLen, txRoot := int(extra[0]), common.BytesToHash(extra[1:])
+	txLen, txRoot := int(extra.TxCount), extra.TxRoot
 	if l := block.Transactions().Len(); l < txLen {
 		return fmt.Errorf("txs length(%d) is less than goat tx length %d", l, txLen)
 	}
@@ -30,6 +33,9 @@ func (v *BlockValidator) validateGoatBlock(block *types.Block) error {
 	if len(block.Withdrawals()) > 0 {
 		return errors.New("withdrawals not allowed for goat-geth")
 	}
+	if err := CheckGoatBeaconRoot(v.config, block.Time(), block.BeaconRoot()); err != nil {
+		return err
+	}
 
 	for i, tx := range block.Transactions() {
 		if i < txLen {
@@ -39,6 +45,9 @@ func (v *BlockValidator) validateGoatBlock(block *types.Block) error {
 			if tx.To() == nil {
 				return fmt.Errorf("goat tx %d should have a to address", i)
 			}
+			if err := CheckGoatTx(goat, tx.AsGoatTx()); err != nil {
+				return fmt.Errorf("goat tx %d: %w", i, err)
+			}
 		} else {
 			if tx.IsGoatTx() {
 				return fmt.Errorf("transaction %d should not be goat tx", i)
@@ -51,3 +60,51 @@ func (v *BlockValidator) validateGoatBlock(block *types.Block) error {
 
 	return nil
 }
+
+// validateGoatState checks the goat requests count and the bitcoin block height
+// committed by the header extra against the processed block.
+func (v *BlockValidator) validateGoatState(header *types.Header, statedb *state.StateDB, res *ProcessResult) error {
+	if v.config.Goat == nil {
+		return nil
+	}
+	goat := v.config.Goat.Params(header.Time)
+	if goat.HeaderExtraVersion < goattypes.HeaderExtraV1 {
+		return nil
+	}
+	extra, err := goattypes.DecodeHeaderExtra(goat, header.Extra)
+	if err != nil {
+		return err
+	}
+	count, err := goatRequestsCount(res.Requests)
+	if err != nil {
+		return err
+	}
+	if extra.RequestsCount != count {
+		return fmt.Errorf("goat requests count mismatch (header value %d, calculated %d)", extra.RequestsCount, count)
+	}
+	if height := goatBtcHeight(statedb); extra.BtcHeight != height {
+		return fmt.Errorf("btc block height mismatch (header value %d, calculated %d)", extra.BtcHeight, height)
+	}
+	return nil
+}
+
+// CheckGoatBeaconRoot checks the consensus layer provides a beacon root if the
+// goat fork processing the EIP-4788 beacon root is active at the given timestamp.
+func CheckGoatBeaconRoot(config *params.ChainConfig, time uint64, beaconRoot *common.Hash) error {
+	if config.Goat == nil || !config.Goat.Params(time).BeaconRoot {
+		return nil
+	}
+	if beaconRoot == nil || *beaconRoot == (common.Hash{}) {
+		return errors.New("missing beacon root from the goat consensus layer")
+	}
+	return nil
+}
+
+// CheckGoatTx checks the module of the goat tx is activated by the goat fork,
+// the relayer goat txs are only accepted since the relayer fork.
+func CheckGoatTx(goat params.GoatParams, tx *types.GoatTx) error {
+	if tx.Module == goattypes.RelayerModule && !goat.RelayerTxs {
+		return fmt.Errorf("goat %s tx is not activated", tx.Module)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// ErrGoatFeeRecordUnavailable is returned if the gas fee distribution of a goat block
+// after the fee split fork is not stored, and the state to derive it is missing.
+// It's the case for the blocks imported with receipts, e.g. snap synced, whose state
+// is not available on the node.
+var ErrGoatFeeRecordUnavailable = errors.New("goat fee record is not stored, and the state to derive it is not available")
+
+// goatBadRequestsLimit is the number of the recent bad blocks whose processed goat
+// requests are retained.
+const goatBadRequestsLimit = 16
+
+// GoatBadBlockRequests returns the goat requests processed by a recent bad block,
+// which failed the validation after it's processed. False is returned if the block
+// is not processed, e.g. it has an invalid tx.
+func (bc *BlockChain) GoatBadBlockRequests(hash common.Hash) ([][]byte, bool) {
+	return bc.goatBadRequests.Get(hash)
+}
+
+// writeGoatFeeRecord stores the gas fee distribution of the goat block, it's
+// a no-op for the other chains. The record is returned by the processor if the
+// block is processed locally. Otherwise it's derived from the receipts, which is
+// skipped after the fee split fork since the fee shares are read from the state
+// after the block. The record of such a block is derived on demand if the state
+// is available, or ErrGoatFeeRecordUnavailable is reported.
+func (bc *BlockChain) writeGoatFeeRecord(db ethdb.KeyValueWriter, block *types.Block, receipts types.Receipts, record *goattypes.FeeRecord) error {
+	if bc.chainConfig.Goat == nil || block.NumberU64() == 0 {
+		return nil
+	}
+	if record == nil {
+		if bc.chainConfig.Goat.Params(block.Time()).FeeSplit {
+			return nil
+		}
+		var err error
+		if record, err = DeriveGoatFeeRecord(bc.chainConfig, block, receipts, nil); err != nil {
+			return err
+		}
+	}
+	rawdb.WriteGoatFeeRecord(db, block.Hash(), block.NumberU64(), record)
+	return nil
+}
@@ -31,7 +31,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
-	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/ethereum/go-verkle"
 	"github.com/holiman/uint256"
@@ -127,12 +126,6 @@ func (b *BlockGen) addTx(bc *BlockChain, vmConfig vm.Config, tx *types.Transacti
 	if b.header.BlobGasUsed != nil {
 		*b.header.BlobGasUsed += receipt.BlobGasUsed
 	}
-
-	if tx.IsGoatTx() {
-		b.header.Extra[0]++
-		hash := types.DeriveSha(types.Transactions(b.txs[:]), trie.NewStackTrie(nil))
-		copy(b.header.Extra[1:], hash[:])
-	}
 }
 
 // AddTx adds a transaction to the generated block. If no coinbase has
@@ -348,6 +341,11 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(b.header.Number) == 0 {
 			misc.ApplyDAOHardFork(statedb)
 		}
+		// The goat consensus layer should provide a non-zero beacon root,
+		// the parent hash is used as a fake one.
+		if config.Goat != nil && config.Goat.Params(b.header.Time).BeaconRoot {
+			b.SetParentBeaconRoot(parent.Hash())
+		}
 		// Execute any user modifications to the block
 		if gen != nil {
 			gen(i, b)
@@ -372,23 +370,34 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 				burntFees.Add(burntFees, blobUsed.Mul(blobUsed, blobBaseFee))
 			}
 
-			var gasFees = new(big.Int)
-			gasFees.Add(gasFees, burntFees)
+			var gasTips = new(big.Int)
 			for i, tx := range b.txs {
 				gasUsed := b.receipts[i].GasUsed
 				if gasUsed == 0 { // It's the goat tx
 					continue
 				}
 				minerFee, _ := tx.EffectiveGasTip(b.header.BaseFee)
-				gasFees.Add(gasFees, new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), minerFee))
+				gasTips.Add(gasTips, new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), minerFee))
 			}
-			gasRevenue := ProcessGoatGasFee(statedb, gasFees)
-			goatRequests, err := ProcessGoatRequests(b.Number().Uint64(), gasRevenue, allLogs)
+			goatFees := ProcessGoatGasFee(config.Goat.Params(b.header.Time), statedb, burntFees, gasTips)
+			goatRequests, err := ProcessGoatRequests(b.Number().Uint64(), goatFees.Revenue, allLogs)
 			if err != nil {
 				panic(fmt.Sprintf("failed to parse goat logs: %v", err))
 			}
 			reqHash := types.CalcRequestsHash(goatRequests)
 			b.header.RequestsHash = &reqHash
+
+			var goatTxs types.Transactions
+			for _, tx := range b.txs {
+				if tx.IsGoatTx() {
+					goatTxs = append(goatTxs, tx)
+				}
+			}
+			extra, err := NewGoatHeaderExtra(config.Goat.Params(b.header.Time), goatTxs, goatRequests, statedb)
+			if err != nil {
+				panic(fmt.Sprintf("failed to make goat header extra: %v", err))
+			}
+			b.header.Extra = extra
 		}
 
 		if config.Goat == nil && config.IsPrague(b.header.Number, b.header.Time) {
@@ -628,8 +637,8 @@ func (cm *chainMaker) makeHeader(parent *types.Block, state *state.StateDB, engi
 	}
 
 	if cm.config.Goat != nil {
-		header.Extra = make([]byte, 1, params.GoatHeaderExtraLengthV0)
-		header.Extra = append(header.Extra, types.EmptyTxsHash[:]...)
+		// The placeholder extra is replaced once the block is generated
+		header.Extra = emptyGoatHeaderExtra(cm.config.Goat.Params(time), 0)
 	}
 	return header
 }
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+)
+
+// AddGoatTx adds a goat tx to the generated block, the nonce of the goat executor
+// is assigned from the block state. The goat txs should be added before the other
+// txs, the goat tx root in the header extra and the goat requests are derived
+// when the block is finalized.
+//
+// AddGoatTx panics if the chain is not a goat chain, the goat tx can't be placed
+// or executed.
+func (b *BlockGen) AddGoatTx(module goattypes.Module, action goattypes.Action, inner goattypes.Tx) *types.Transaction {
+	if b.cm.config.Goat == nil {
+		panic("goat tx is not supported by the chain")
+	}
+	var goatTxs int
+	for _, tx := range b.txs {
+		if !tx.IsGoatTx() {
+			panic("goat tx should be placed before the other transactions")
+		}
+		goatTxs++
+	}
+	if limit := b.cm.config.Goat.Params(b.header.Time).TxLimitPerBlock; goatTxs >= limit {
+		panic(fmt.Sprintf("too many goat txs: max %d", limit))
+	}
+	tx := types.NewTx(types.NewGoatTx(module, action, b.statedb.GetNonce(inner.Sender()), inner))
+	if err := CheckGoatTx(b.cm.config.Goat.Params(b.header.Time), tx.AsGoatTx()); err != nil {
+		panic(err)
+	}
+	b.AddTx(tx)
+	return tx
+}
+
+// AddDeposit adds a bridge deposit goat tx to the generated block.
+func (b *BlockGen) AddDeposit(txid common.Hash, txout uint32, target common.Address, amount *big.Int) *types.Transaction {
+	tx := &goattypes.DepositTx{Txid: txid, TxOut: txout, Target: target, Amount: amount}
+	return b.AddGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, tx)
+}
+
+// AddDistributeReward adds a locking distribute reward goat tx to the generated block.
+func (b *BlockGen) AddDistributeReward(id uint64, recipient common.Address, goat, gasReward *big.Int) *types.Transaction {
+	tx := &goattypes.DistributeRewardTx{Id: id, Recipient: recipient, Goat: goat, GasReward: gasReward}
+	return b.AddGoatTx(goattypes.LockingModule, goattypes.LockingDistributeRewardAction, tx)
+}
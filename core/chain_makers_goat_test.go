@@ -0,0 +1,152 @@
+package core
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestGenerateGoatChain(t *testing.T) {
+	var (
+		engine = beacon.NewFaker()
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = DeveloperGoatGenesisBlock(30_000_000, &addr)
+		target = common.HexToAddress("0x0d1b10d13d3c393206ff5c5136c7f86e3ad390ad")
+		amount = big.NewInt(params.Ether)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *BlockGen) {
+		b.AddDeposit(common.BigToHash(big.NewInt(int64(i+1))), 0, target, amount)
+		b.AddDistributeReward(0, target, new(big.Int), new(big.Int))
+
+		tx, _ := types.SignNewTx(key, types.LatestSigner(gspec.Config), &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     uint64(i),
+			To:        &target,
+			Gas:       params.TxGas,
+			GasFeeCap: b.BaseFee(),
+		})
+		b.AddTx(tx)
+	})
+
+	for i, block := range blocks {
+		goatTxs := block.Transactions()[:2]
+		for j, tx := range goatTxs {
+			if !tx.IsGoatTx() || tx.Nonce() != uint64(i) {
+				t.Fatalf("block %d: unexpected goat tx %d, nonce %d", block.NumberU64(), j, tx.Nonce())
+			}
+		}
+		extra, err := goattypes.DecodeHeaderExtra(gspec.Config.Goat.Params(block.Time()), block.Extra())
+		if err != nil {
+			t.Fatalf("block %d: failed to decode the header extra: %v", block.NumberU64(), err)
+		}
+		if root := types.DeriveSha(goatTxs, trie.NewStackTrie(nil)); extra.TxCount != 2 || extra.TxRoot != root {
+			t.Errorf("block %d: header extra mismatch: have %d %x, want 2 %x", block.NumberU64(), extra.TxCount, extra.TxRoot, root)
+		}
+	}
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert the goat chain: %v", err)
+	}
+	state, _ := chain.State()
+	if balance := state.GetBalance(target); balance.Sign() == 0 {
+		t.Error("deposits are not credited to the target")
+	}
+}
+
+func TestAddGoatTxPlacement(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = DeveloperGoatGenesisBlock(30_000_000, &addr)
+	)
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for the goat tx after a normal tx")
+		}
+	}()
+	GenerateChainWithGenesis(gspec, beacon.NewFaker(), 1, func(i int, b *BlockGen) {
+		tx, _ := types.SignNewTx(key, types.LatestSigner(gspec.Config), &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			To:        &addr,
+			Gas:       params.TxGas,
+			GasFeeCap: b.BaseFee(),
+		})
+		b.AddTx(tx)
+		b.AddDeposit(common.Hash{1}, 0, addr, big.NewInt(params.Ether))
+	})
+}
+
+func TestGoatRelayerTxFork(t *testing.T) {
+	var (
+		engine = beacon.NewFaker()
+		gspec  = DeveloperGoatGenesisBlock(30_000_000, nil)
+		voter  = &goattypes.AddVoterTx{Voter: common.HexToAddress("0x0d1b10d13d3c393206ff5c5136c7f86e3ad390ad"), Pubkey: common.Hash{1}}
+	)
+	if !gspec.Config.Goat.Params(0).RelayerTxs {
+		t.Fatal("relayer goat txs should be activated in the developer genesis")
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 1, nil)
+
+	// Replace the txs of the generated block with a relayer goat tx
+	txs := types.Transactions{types.NewTx(types.NewGoatTx(goattypes.RelayerModule, goattypes.RelayerAddVoterAction, 0, voter))}
+	header := blocks[0].Header()
+	extra, err := NewGoatHeaderExtra(params.GoatParamsV0, txs, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.Extra = extra
+	block := types.NewBlock(header, &types.Body{Transactions: txs, Withdrawals: blocks[0].Withdrawals()}, nil, trie.NewStackTrie(nil))
+
+	for _, c := range []struct {
+		name  string
+		goat  *params.GoatConfig
+		valid bool
+	}{
+		{"relayer", gspec.Config.Goat, true},
+		{"legacy", &params.GoatConfig{}, false},
+	} {
+		config := *gspec.Config
+		config.Goat = c.goat
+		genesis := *gspec
+		genesis.Config = &config
+		chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, &genesis, nil, engine, vm.Config{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = chain.Validator().ValidateBody(block)
+		chain.Stop()
+		if c.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+		if !c.valid && (err == nil || !strings.Contains(err.Error(), "goat relayer tx is not activated")) {
+			t.Errorf("%s: unexpected error for the relayer goat tx before the fork: %v", c.name, err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for the relayer goat tx before the fork")
+		}
+	}()
+	legacy := *gspec
+	legacy.Config = params.AllGoatDebugChainConfig
+	GenerateChainWithGenesis(&legacy, engine, 1, func(i int, b *BlockGen) {
+		b.AddGoatTx(goattypes.RelayerModule, goattypes.RelayerAddVoterAction, voter)
+	})
+}
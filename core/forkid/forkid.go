@@ -269,6 +269,12 @@ func gatherForks(config *params.ChainConfig, genesis uint64) ([]uint64, []uint64
 			}
 		}
 	}
+	// Goat forks are scheduled by timestamps in the goat config
+	if config.Goat != nil {
+		for _, fork := range config.Goat.Forks {
+			forksByTime = append(forksByTime, fork.Time)
+		}
+	}
 	slices.Sort(forksByBlock)
 	slices.Sort(forksByTime)
 
@@ -108,6 +108,15 @@ func TestCreation(t *testing.T) {
 				{123, 2707305664, ID{Hash: checksumToBytes(0x9b192ad0), Next: 0}},          // Future Cancun block
 			},
 		},
+		// Goat testnet test cases
+		{
+			params.GoatTestnetChainConfig,
+			core.DefaultGoatTestnetGenesisBlock().ToBlock(),
+			[]testcase{
+				{0, 0, ID{Hash: checksumToBytes(0xa1f3dd36), Next: 0}},               // Unsynced, all forks are enabled in genesis
+				{100000, 2000000000, ID{Hash: checksumToBytes(0xa1f3dd36), Next: 0}}, // Future block
+			},
+		},
 	}
 	for i, tt := range tests {
 		for j, ttt := range tt.cases {
@@ -201,6 +201,8 @@ func getGenesisState(db ethdb.Database, blockhash common.Hash) (alloc types.Gene
 		genesis = DefaultSepoliaGenesisBlock()
 	case params.HoleskyGenesisHash:
 		genesis = DefaultHoleskyGenesisBlock()
+	case params.GoatTestnetGenesisHash:
+		genesis = DefaultGoatTestnetGenesisBlock()
 	}
 	if genesis != nil {
 		return genesis.Alloc, nil
@@ -261,6 +263,11 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, triedb *triedb.Database, g
 	if genesis != nil && genesis.Config == nil {
 		return params.AllEthashProtocolChanges, common.Hash{}, errGenesisNoConfig
 	}
+	if genesis != nil && genesis.Config.Goat != nil {
+		if err := genesis.VerifyGoatGenesis(); err != nil {
+			return genesis.Config, common.Hash{}, err
+		}
+	}
 	applyOverrides := func(config *params.ChainConfig) {
 		if config != nil {
 			if overrides != nil && overrides.OverrideCancun != nil {
@@ -399,6 +406,8 @@ func (g *Genesis) configOrDefault(ghash common.Hash) *params.ChainConfig {
 		return params.HoleskyChainConfig
 	case ghash == params.SepoliaGenesisHash:
 		return params.SepoliaChainConfig
+	case ghash == params.GoatTestnetGenesisHash:
+		return params.GoatTestnetChainConfig
 	default:
 		return params.AllEthashProtocolChanges
 	}
@@ -3,21 +3,23 @@ package core
 import (
 	"embed"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 
 	_ "embed"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/params"
 )
 
 //go:embed goat
 var goatGenesis embed.FS
 
-// DefaultGoatTestnetGenesisBlock returns the Goat Testnet genesis block.
-func DefaultGoatTestnetGenesisBlock() *Genesis {
-	raw, err := goatGenesis.ReadFile("goat/testnet.json")
+func readGoatGenesisAlloc(name string) types.GenesisAlloc {
+	raw, err := goatGenesis.ReadFile(name)
 	if err != nil {
 		panic(err)
 	}
@@ -25,6 +27,11 @@ func DefaultGoatTestnetGenesisBlock() *Genesis {
 	if err := json.Unmarshal(raw, &alloc); err != nil {
 		panic(err)
 	}
+	return alloc
+}
+
+// DefaultGoatTestnetGenesisBlock returns the Goat Testnet genesis block.
+func DefaultGoatTestnetGenesisBlock() *Genesis {
 	return &Genesis{
 		Config:     params.GoatTestnetChainConfig,
 		Nonce:      0,
@@ -32,7 +39,87 @@ func DefaultGoatTestnetGenesisBlock() *Genesis {
 		ExtraData:  common.Hex2Bytes("0056e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"),
 		GasLimit:   0x1c9c380,
 		Difficulty: big.NewInt(1),
-		Alloc:      alloc,
+		Alloc:      readGoatGenesisAlloc("goat/testnet.json"),
 		BaseFee:    big.NewInt(500000000),
 	}
 }
+
+// VerifyGoatGenesis checks the goat system contracts are deployed in the genesis
+// and the header extra commits to an empty goat tx list.
+func (g *Genesis) VerifyGoatGenesis() error {
+	if g.Config == nil || g.Config.Goat == nil {
+		return errors.New("not a goat genesis")
+	}
+	if err := g.Config.CheckConfigForkOrder(); err != nil {
+		return err
+	}
+
+	for _, addr := range goattypes.SystemContracts {
+		if account, ok := g.Alloc[addr]; !ok || len(account.Code) == 0 {
+			return fmt.Errorf("goat system contract %s has no code", addr)
+		}
+	}
+
+	goat := g.Config.Goat.Params(g.Timestamp)
+	extra, err := goattypes.DecodeHeaderExtra(goat, g.ExtraData)
+	if err != nil {
+		return fmt.Errorf("invalid goat genesis extra: %w", err)
+	}
+	if extra.TxCount != 0 {
+		return fmt.Errorf("goat genesis should not have goat txs: have %d", extra.TxCount)
+	}
+	if extra.TxRoot != types.EmptyTxsHash {
+		return fmt.Errorf("invalid goat tx root in genesis extra: have %x, want %x", extra.TxRoot, types.EmptyTxsHash)
+	}
+	if extra.RequestsCount != 0 {
+		return fmt.Errorf("goat genesis should not have goat requests: have %d", extra.RequestsCount)
+	}
+	if height := goatGenesisBtcHeight(goat, g.Alloc); extra.BtcHeight != height {
+		return fmt.Errorf("invalid btc height in genesis extra: have %d, want %d", extra.BtcHeight, height)
+	}
+	return nil
+}
+
+// emptyGoatHeaderExtra returns the header extra committing to an empty goat tx list
+func emptyGoatHeaderExtra(goat params.GoatParams, btcHeight uint64) []byte {
+	extra := &goattypes.HeaderExtra{Version: goat.HeaderExtraVersion, TxRoot: types.EmptyTxsHash, BtcHeight: btcHeight}
+	raw, err := extra.Encode(goat)
+	if err != nil {
+		panic(err)
+	}
+	return raw
+}
+
+// goatGenesisBtcHeight returns the bitcoin block height committed by the genesis
+// header extra, it's the start height of the bitcoin contract since the V1 layout.
+func goatGenesisBtcHeight(goat params.GoatParams, alloc types.GenesisAlloc) uint64 {
+	if goat.HeaderExtraVersion < goattypes.HeaderExtraV1 {
+		return 0
+	}
+	return alloc[goattypes.BitcoinContract].Storage[goattypes.BitcoinLatestHeightSlot].Big().Uint64()
+}
+
+// DeveloperGoatGenesisBlock returns the 'geth --dev --dev.goat' genesis block.
+// It deploys the goat system contracts of the testnet and prefunds the faucet.
+func DeveloperGoatGenesisBlock(gasLimit uint64, faucet *common.Address) *Genesis {
+	config := *params.AllGoatDebugChainConfig
+	// The relayer goat txs are accepted since the genesis of the developer chain
+	relayer := params.GoatParamsV0
+	relayer.RelayerTxs = true
+	config.Goat = &params.GoatConfig{Forks: []*params.GoatFork{{Name: "relayer", GoatParams: relayer}}}
+
+	genesis := &Genesis{
+		Config:     &config,
+		GasLimit:   gasLimit,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Difficulty: big.NewInt(0),
+		Alloc:      readGoatGenesisAlloc("goat/testnet.json"),
+	}
+	goat := config.Goat.Params(genesis.Timestamp)
+	genesis.ExtraData = emptyGoatHeaderExtra(goat, goatGenesisBtcHeight(goat, genesis.Alloc))
+	if faucet != nil {
+		// Leave headroom for the bridge deposits minted to the faucet
+		genesis.Alloc[*faucet] = types.Account{Balance: new(big.Int).Lsh(big.NewInt(1), 128)}
+	}
+	return genesis
+}
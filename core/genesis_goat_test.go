@@ -0,0 +1,150 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+func TestVerifyGoatGenesis(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(g *Genesis)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(g *Genesis) {}},
+		{
+			name:    "not goat",
+			modify:  func(g *Genesis) { g.Config = params.AllDevChainProtocolChanges },
+			wantErr: true,
+		},
+		{
+			name:    "no bridge code",
+			modify:  func(g *Genesis) { delete(g.Alloc, goattypes.BridgeContract) },
+			wantErr: true,
+		},
+		{
+			name: "empty locking code",
+			modify: func(g *Genesis) {
+				account := g.Alloc[goattypes.LockingContract]
+				account.Code = nil
+				g.Alloc[goattypes.LockingContract] = account
+			},
+			wantErr: true,
+		},
+		{
+			name:    "short extra",
+			modify:  func(g *Genesis) { g.ExtraData = g.ExtraData[:32] },
+			wantErr: true,
+		},
+		{
+			name:    "non-empty goat tx count",
+			modify:  func(g *Genesis) { g.ExtraData[0] = 1 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid goat tx root",
+			modify:  func(g *Genesis) { g.ExtraData = append([]byte{0}, common.Hash{}.Bytes()...) },
+			wantErr: true,
+		},
+		{
+			name: "longer extra after the goat fork",
+			modify: func(g *Genesis) {
+				config := *g.Config
+				forked := params.GoatParamsV0
+				forked.HeaderExtraLength = 40
+				config.Goat = &params.GoatConfig{Forks: []*params.GoatFork{{Time: g.Timestamp, GoatParams: forked}}}
+				g.Config = &config
+				g.ExtraData = append(g.ExtraData, make([]byte, 7)...)
+			},
+		},
+		{
+			name: "non-zero reserved extra",
+			modify: func(g *Genesis) {
+				config := *g.Config
+				forked := params.GoatParamsV0
+				forked.HeaderExtraLength = 40
+				config.Goat = &params.GoatConfig{Forks: []*params.GoatFork{{Time: g.Timestamp, GoatParams: forked}}}
+				g.Config = &config
+				g.ExtraData = append(g.ExtraData, 0, 0, 0, 0, 0, 0, 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "v1 extra committing the btc start height",
+			modify: func(g *Genesis) {
+				forked := setGoatHeaderExtraV1(g)
+				g.ExtraData = emptyGoatHeaderExtra(forked, goatGenesisBtcHeight(forked, g.Alloc))
+			},
+		},
+		{
+			name: "v1 extra committing a wrong btc height",
+			modify: func(g *Genesis) {
+				g.ExtraData = emptyGoatHeaderExtra(setGoatHeaderExtraV1(g), 0)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genesis := DefaultGoatTestnetGenesisBlock()
+			genesis.ExtraData = common.CopyBytes(genesis.ExtraData)
+			tt.modify(genesis)
+			if err := genesis.VerifyGoatGenesis(); (err != nil) != tt.wantErr {
+				t.Errorf("VerifyGoatGenesis() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := DefaultGoatTestnetGenesisBlock().VerifyGoatGenesis(); err != nil {
+		t.Errorf("invalid goat testnet genesis: %v", err)
+	}
+}
+
+func TestSetupGoatGenesis(t *testing.T) {
+	// The genesis without the goat system contracts is rejected
+	genesis := &Genesis{Config: params.AllGoatDebugChainConfig}
+	db := rawdb.NewMemoryDatabase()
+	if _, _, err := SetupGenesisBlock(db, triedb.NewDatabase(db, nil), genesis); err == nil {
+		t.Fatal("expected error for the goat genesis without the system contracts")
+	}
+	if hash := rawdb.ReadCanonicalHash(db, 0); hash != (common.Hash{}) {
+		t.Fatalf("invalid goat genesis is committed: %x", hash)
+	}
+
+	genesis = DeveloperGoatGenesisBlock(30_000_000, nil)
+	if _, hash, err := SetupGenesisBlock(db, triedb.NewDatabase(db, nil), genesis); err != nil || hash != genesis.ToBlock().Hash() {
+		t.Fatalf("failed to setup the goat genesis: %x %v", hash, err)
+	}
+}
+
+// setupGoatTestGenesis deploys the code of the goat system contracts missing in
+// the test genesis, the balances and storage of the test accounts are kept. The
+// header extra is committed to an empty goat tx list.
+func setupGoatTestGenesis(g *Genesis) *Genesis {
+	testnet := readGoatGenesisAlloc("goat/testnet.json")
+	for _, addr := range goattypes.SystemContracts {
+		if account := g.Alloc[addr]; len(account.Code) == 0 {
+			account.Code = testnet[addr].Code
+			g.Alloc[addr] = account
+		}
+	}
+	goat := g.Config.Goat.Params(g.Timestamp)
+	g.ExtraData = emptyGoatHeaderExtra(goat, goatGenesisBtcHeight(goat, g.Alloc))
+	return g
+}
+
+// setGoatHeaderExtraV1 activates the V1 header extra layout since the genesis
+func setGoatHeaderExtraV1(g *Genesis) params.GoatParams {
+	config := *g.Config
+	forked := params.GoatParamsV0
+	forked.HeaderExtraVersion = goattypes.HeaderExtraV1
+	forked.HeaderExtraLength = params.GoatHeaderExtraLengthV1
+	config.Goat = &params.GoatConfig{Forks: []*params.GoatFork{{Time: g.Timestamp, GoatParams: forked}}}
+	g.Config = &config
+	return forked
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type goatDepositKey struct {
+	txid  common.Hash
+	txout uint32
+}
+
+// GoatBridgeIndexer implements a core.ChainIndexer, recording the blocks where
+// the state of a bridge withdrawal or deposit changed and the bitcoin block
+// hashes fed into the bitcoin contract.
+type GoatBridgeIndexer struct {
+	size uint64         // section size to index
+	db   ethdb.Database // database instance to read blocks and write the index into
+
+	section     uint64                                     // Section is the section number being processed currently
+	withdrawals map[uint64][]goattypes.BridgeEvent         // withdrawal events of the current section
+	deposits    map[goatDepositKey][]goattypes.BridgeEvent // deposit events of the current section
+	btcBlocks   map[uint64]*goattypes.BtcBlockRecord       // bitcoin blocks of the current section
+}
+
+// NewGoatBridgeIndexer returns a chain indexer that records the bridge withdrawal
+// and deposit events of the canonical chain.
+func NewGoatBridgeIndexer(db ethdb.Database, size, confirms uint64) *ChainIndexer {
+	backend := &GoatBridgeIndexer{
+		db:   db,
+		size: size,
+	}
+	table := rawdb.NewTable(db, string(rawdb.GoatBridgeIndexPrefix))
+
+	return NewChainIndexer(db, table, backend, size, confirms, 0, "goatbridge")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new bridge index section.
+func (b *GoatBridgeIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	b.section = section
+	b.withdrawals = make(map[uint64][]goattypes.BridgeEvent)
+	b.deposits = make(map[goatDepositKey][]goattypes.BridgeEvent)
+	b.btcBlocks = make(map[uint64]*goattypes.BtcBlockRecord)
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, collecting the bridge events of
+// a new header.
+func (b *GoatBridgeIndexer) Process(ctx context.Context, header *types.Header) error {
+	var (
+		hash   = header.Hash()
+		number = header.Number.Uint64()
+	)
+	body := rawdb.ReadBody(b.db, hash, number)
+	if body == nil {
+		return errors.New("block body not found")
+	}
+	receipts := rawdb.ReadRawReceipts(b.db, hash, number)
+	if len(receipts) != len(body.Transactions) {
+		return errors.New("block receipts not found")
+	}
+
+	for i, tx := range body.Transactions {
+		if receipts[i].Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		event := goattypes.BridgeEvent{BlockNumber: number, BlockHash: hash, TxHash: tx.Hash()}
+		var btcBlock *goattypes.NewBtcBlockTx
+		if goatTx := tx.AsGoatTx(); goatTx != nil {
+			switch inner := goatTx.Inner().(type) {
+			case *goattypes.NewBtcBlockTx:
+				btcBlock = inner
+			case *goattypes.PaidTx:
+				event.Kind = goattypes.BridgePaidEvent
+				b.addWithdrawalEvent(inner.Id, event)
+			case *goattypes.Cancel2Tx:
+				event.Kind = goattypes.BridgeCancel2Event
+				b.addWithdrawalEvent(inner.Id, event)
+			}
+		}
+		for _, l := range receipts[i].Logs {
+			if btcBlock != nil && l.Address == goattypes.BitcoinContract && len(l.Topics) != 0 && l.Topics[0] == goattypes.NewBlockHashEventTopic {
+				height, err := goattypes.UnpackToNewBlockHashEvent(l.Topics, l.Data)
+				if err != nil {
+					return err
+				}
+				b.btcBlocks[height] = &goattypes.BtcBlockRecord{Hash: btcBlock.Hash, BlockNumber: number, BlockHash: hash, TxHash: event.TxHash}
+				continue
+			}
+			if l.Address != goattypes.BridgeContract || len(l.Topics) == 0 {
+				continue
+			}
+			switch l.Topics[0] {
+			case goattypes.WithdrawEventTopic:
+				req, err := goattypes.UnpackIntoWithdrawRequest(l.Topics, l.Data)
+				if err != nil {
+					return err
+				}
+				event.Kind = goattypes.BridgeWithdrawEvent
+				b.withdrawals[req.Id] = append(b.withdrawals[req.Id], event)
+			case goattypes.ReplaceByFeeEventTopic:
+				req, err := goattypes.UnpackIntoReplaceByFeeRequest(l.Topics, l.Data)
+				if err != nil {
+					return err
+				}
+				event.Kind = goattypes.BridgeReplaceByFeeEvent
+				b.withdrawals[req.Id] = append(b.withdrawals[req.Id], event)
+			case goattypes.Cancel1EventTopic:
+				req, err := goattypes.UnpackIntoCancel1Request(l.Topics, l.Data)
+				if err != nil {
+					return err
+				}
+				event.Kind = goattypes.BridgeCancel1Event
+				b.withdrawals[req.Id] = append(b.withdrawals[req.Id], event)
+			case goattypes.DepositEventTopic:
+				deposit, err := goattypes.UnpackToDepositEvent(l.Topics, l.Data)
+				if err != nil {
+					return err
+				}
+				event.Kind = goattypes.BridgeDepositEvent
+				key := goatDepositKey{deposit.Txid, deposit.TxOut}
+				b.deposits[key] = append(b.deposits[key], event)
+			}
+		}
+	}
+	return nil
+}
+
+// addWithdrawalEvent records the event of the withdrawal id in the goat tx
+func (b *GoatBridgeIndexer) addWithdrawalEvent(id *big.Int, event goattypes.BridgeEvent) {
+	if !id.IsUint64() {
+		log.Warn("Invalid goat withdrawal id", "id", id, "tx", event.TxHash)
+		return
+	}
+	b.withdrawals[id.Uint64()] = append(b.withdrawals[id.Uint64()], event)
+}
+
+// Commit implements core.ChainIndexerBackend, appending the bridge events of the
+// section to the database. The events of a re-processed section are replaced.
+func (b *GoatBridgeIndexer) Commit() error {
+	var (
+		start = b.section * b.size
+		batch = b.db.NewBatch()
+	)
+	for id, events := range b.withdrawals {
+		stored := truncateGoatBridgeEvents(rawdb.ReadGoatWithdrawalEvents(b.db, id), start)
+		rawdb.WriteGoatWithdrawalEvents(batch, id, append(stored, events...))
+	}
+	for key, events := range b.deposits {
+		stored := truncateGoatBridgeEvents(rawdb.ReadGoatDepositEvents(b.db, key.txid, key.txout), start)
+		rawdb.WriteGoatDepositEvents(batch, key.txid, key.txout, append(stored, events...))
+	}
+	// The tip is rewritten by a re-processed section, the stale tip of a reorged
+	// chain is skipped by the readers.
+	if len(b.btcBlocks) != 0 {
+		var tip uint64
+		for height, record := range b.btcBlocks {
+			rawdb.WriteGoatBtcBlock(batch, height, record)
+			tip = max(tip, height)
+		}
+		rawdb.WriteGoatBtcTip(batch, tip)
+	}
+	log.Debug("Indexed goat bridge events", "section", b.section, "withdrawals", len(b.withdrawals), "deposits", len(b.deposits), "btcblocks", len(b.btcBlocks))
+	return batch.Write()
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (b *GoatBridgeIndexer) Prune(threshold uint64) error {
+	return nil
+}
+
+// truncateGoatBridgeEvents drops the events at or after the given block number
+func truncateGoatBridgeEvents(events []goattypes.BridgeEvent, number uint64) []goattypes.BridgeEvent {
+	for i, event := range events {
+		if event.BlockNumber >= number {
+			return events[:i]
+		}
+	}
+	return events
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestGoatBridgeIndexer(t *testing.T) {
+	var (
+		db   = rawdb.NewMemoryDatabase()
+		to   = common.HexToAddress("0x4a284d2835a3497e08b8b7fb30459a1c8229553d")
+		txid = common.HexToHash("0x26700e13983fefbd9cf16da2ed70fa5c6798ac55062a4803121a869731e308d2")
+
+		withdrawLog = &types.Log{
+			Address: goattypes.BridgeContract,
+			Topics: []common.Hash{
+				goattypes.WithdrawEventTopic,
+				common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000064"),
+				common.HexToHash("0x0000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc4"),
+			},
+			Data: hexutil.MustDecode("0x000000000000000000000000000000000000000000000000000000174876e800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000080000000000000000000000000000000000000000000000000000000000000003e62633171656e356b76336330657064397966717675327130353971736a7077753968646a797778327639703570396c386d73786e383866733979356b78360000"),
+		}
+		depositLog = &types.Log{
+			Address: goattypes.BridgeContract,
+			Topics: []common.Hash{
+				goattypes.DepositEventTopic,
+				common.HexToHash("0x0000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc4"),
+				common.HexToHash("0x0000000000000000000000000000000000000000000000000000000005f5e100"),
+			},
+			Data: hexutil.MustDecode("0x26700e13983fefbd9cf16da2ed70fa5c6798ac55062a4803121a869731e308d2000000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000002710"),
+		}
+		newBlockHashLog = &types.Log{
+			Address: goattypes.BitcoinContract,
+			Topics:  []common.Hash{goattypes.NewBlockHashEventTopic},
+			Data:    common.LeftPadBytes([]byte{0x30, 0xb3, 0x64}, 32),
+		}
+	)
+
+	// block 1 requests the withdrawal 100 and block 2 pays it with a deposit
+	var (
+		withdraw = types.NewTx(&types.LegacyTx{Nonce: 0, To: &to, Gas: 21000, GasPrice: big.NewInt(1)})
+		paid     = types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgePaidAction, 0,
+			&goattypes.PaidTx{Id: big.NewInt(100), Txid: common.HexToHash("0x02"), TxOut: 1, Amount: big.NewInt(10)}))
+		deposit = types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, 1,
+			&goattypes.DepositTx{Txid: txid, TxOut: 10, Target: to, Amount: big.NewInt(100000000)}))
+		failed   = types.NewTx(&types.LegacyTx{Nonce: 1, To: &to, Gas: 21000, GasPrice: big.NewInt(1)})
+		btcBlock = types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BitcoinNewBlockAction, 2,
+			&goattypes.NewBtcBlockTx{Hash: common.HexToHash("0x03")}))
+	)
+	blocks := []struct {
+		txs      types.Transactions
+		receipts types.Receipts
+	}{
+		{
+			types.Transactions{withdraw},
+			types.Receipts{{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{withdrawLog}}},
+		},
+		{
+			types.Transactions{paid, deposit, btcBlock, failed},
+			types.Receipts{
+				{Status: types.ReceiptStatusSuccessful},
+				{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{depositLog}},
+				{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{newBlockHashLog}},
+				{Status: types.ReceiptStatusFailed, Logs: []*types.Log{withdrawLog}},
+			},
+		},
+	}
+	var headers []*types.Header
+	for i, b := range blocks {
+		header := &types.Header{Number: big.NewInt(int64(i + 1)), Difficulty: common.Big0}
+		block := types.NewBlock(header, &types.Body{Transactions: b.txs}, b.receipts, trie.NewStackTrie(nil))
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), b.receipts)
+		headers = append(headers, block.Header())
+	}
+
+	indexer := &GoatBridgeIndexer{db: db, size: 4}
+	process := func() {
+		if err := indexer.Reset(context.Background(), 0, common.Hash{}); err != nil {
+			t.Fatal(err)
+		}
+		for _, header := range headers {
+			if err := indexer.Process(context.Background(), header); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := indexer.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check := func() {
+		wantWithdrawal := []goattypes.BridgeEvent{
+			{Kind: goattypes.BridgeWithdrawEvent, BlockNumber: 1, BlockHash: headers[0].Hash(), TxHash: withdraw.Hash()},
+			{Kind: goattypes.BridgePaidEvent, BlockNumber: 2, BlockHash: headers[1].Hash(), TxHash: paid.Hash()},
+		}
+		if got := rawdb.ReadGoatWithdrawalEvents(db, 100); !reflect.DeepEqual(got, wantWithdrawal) {
+			t.Errorf("unexpected withdrawal events: have %v, want %v", got, wantWithdrawal)
+		}
+		wantDeposit := []goattypes.BridgeEvent{
+			{Kind: goattypes.BridgeDepositEvent, BlockNumber: 2, BlockHash: headers[1].Hash(), TxHash: deposit.Hash()},
+		}
+		if got := rawdb.ReadGoatDepositEvents(db, txid, 10); !reflect.DeepEqual(got, wantDeposit) {
+			t.Errorf("unexpected deposit events: have %v, want %v", got, wantDeposit)
+		}
+		wantBtcBlock := &goattypes.BtcBlockRecord{Hash: common.HexToHash("0x03"), BlockNumber: 2, BlockHash: headers[1].Hash(), TxHash: btcBlock.Hash()}
+		if got := rawdb.ReadGoatBtcBlock(db, 0x30b364); !reflect.DeepEqual(got, wantBtcBlock) {
+			t.Errorf("unexpected btc block: have %v, want %v", got, wantBtcBlock)
+		}
+		if tip := rawdb.ReadGoatBtcTip(db); tip == nil || *tip != 0x30b364 {
+			t.Errorf("unexpected btc tip: have %v, want %d", tip, 0x30b364)
+		}
+	}
+	process()
+	check()
+
+	// The re-processed section replaces the events instead of appending again
+	process()
+	check()
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// GoatLockingIndexer implements a core.ChainIndexer, recording the locking requests
+// and the locking goat txs of every validator.
+type GoatLockingIndexer struct {
+	size uint64         // section size to index
+	db   ethdb.Database // database instance to read blocks and write the index into
+
+	section    uint64                                      // Section is the section number being processed currently
+	validators map[common.Address][]goattypes.LockingEvent // validator events of the current section
+	params     []goattypes.LockingEvent                    // grant and token update events of the current section
+	unlocks    map[uint64]*goattypes.LockingRequestRecord  // validators of the unlock requests of the current section
+	claims     map[uint64]*goattypes.LockingRequestRecord  // validators of the claim requests of the current section
+}
+
+// NewGoatLockingIndexer returns a chain indexer that records the locking events
+// of the canonical chain.
+func NewGoatLockingIndexer(db ethdb.Database, size, confirms uint64) *ChainIndexer {
+	backend := &GoatLockingIndexer{
+		db:   db,
+		size: size,
+	}
+	table := rawdb.NewTable(db, string(rawdb.GoatLockingIndexPrefix))
+
+	return NewChainIndexer(db, table, backend, size, confirms, 0, "goatlocking")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new locking index section.
+func (b *GoatLockingIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	b.section = section
+	b.validators = make(map[common.Address][]goattypes.LockingEvent)
+	b.params = nil
+	b.unlocks = make(map[uint64]*goattypes.LockingRequestRecord)
+	b.claims = make(map[uint64]*goattypes.LockingRequestRecord)
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, collecting the locking events of
+// a new header.
+func (b *GoatLockingIndexer) Process(ctx context.Context, header *types.Header) error {
+	var (
+		hash   = header.Hash()
+		number = header.Number.Uint64()
+	)
+	body := rawdb.ReadBody(b.db, hash, number)
+	if body == nil {
+		return errors.New("block body not found")
+	}
+	receipts := rawdb.ReadRawReceipts(b.db, hash, number)
+	if len(receipts) != len(body.Transactions) {
+		return errors.New("block receipts not found")
+	}
+
+	for i, tx := range body.Transactions {
+		if receipts[i].Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		event := goattypes.LockingEvent{BlockNumber: number, BlockHash: hash, TxHash: tx.Hash()}
+		if goatTx := tx.AsGoatTx(); goatTx != nil {
+			switch inner := goatTx.Inner().(type) {
+			case *goattypes.CompleteUnlockTx:
+				event.Kind, event.Data = goattypes.LockingCompleteUnlockEvent, inner.Encode()
+				b.addRequestedEvent(b.unlocks, rawdb.ReadGoatUnlockRequest, inner.Id, event)
+			case *goattypes.DistributeRewardTx:
+				event.Kind, event.Data = goattypes.LockingDistributeRewardEvent, inner.Encode()
+				b.addRequestedEvent(b.claims, rawdb.ReadGoatClaimRequest, inner.Id, event)
+			}
+		}
+		reqs, err := goatLockingRequests(number, receipts[i].Logs)
+		if err != nil {
+			return err
+		}
+		record := func(validator common.Address) *goattypes.LockingRequestRecord {
+			return &goattypes.LockingRequestRecord{Validator: validator, BlockNumber: number, BlockHash: hash}
+		}
+		for _, req := range reqs.Creates {
+			event.Kind, event.Data = goattypes.LockingCreateEvent, req.Encode()
+			b.validators[req.Validator] = append(b.validators[req.Validator], event)
+		}
+		for _, req := range reqs.Locks {
+			event.Kind, event.Data = goattypes.LockingLockEvent, req.Encode()
+			b.validators[req.Validator] = append(b.validators[req.Validator], event)
+		}
+		for _, req := range reqs.Unlocks {
+			event.Kind, event.Data = goattypes.LockingUnlockEvent, req.Encode()
+			b.validators[req.Validator] = append(b.validators[req.Validator], event)
+			b.unlocks[req.Id] = record(req.Validator)
+		}
+		for _, req := range reqs.Claims {
+			event.Kind, event.Data = goattypes.LockingClaimEvent, req.Encode()
+			b.validators[req.Validator] = append(b.validators[req.Validator], event)
+			b.claims[req.Id] = record(req.Validator)
+		}
+		for _, req := range reqs.Grants {
+			event.Kind, event.Data = goattypes.LockingGrantEvent, req.Encode()
+			b.params = append(b.params, event)
+		}
+		for _, req := range reqs.UpdateWeights {
+			event.Kind, event.Data = goattypes.LockingUpdateWeightEvent, req.Encode()
+			b.params = append(b.params, event)
+		}
+		for _, req := range reqs.UpdateThresholds {
+			event.Kind, event.Data = goattypes.LockingUpdateThresholdEvent, req.Encode()
+			b.params = append(b.params, event)
+		}
+	}
+	return nil
+}
+
+// goatLockingRequests returns the locking requests emitted by the logs of a tx,
+// which are decoded from the goat requests of the logs as the consensus layer
+// receives them.
+func goatLockingRequests(number uint64, logs []*types.Log) (*goattypes.LockingRequests, error) {
+	requests, err := ProcessGoatRequests(number, new(big.Int), logs)
+	if err != nil {
+		return nil, err
+	}
+	_, _, locking, err := goattypes.DecodeRequests(requests, true)
+	if err != nil {
+		return nil, err
+	}
+	return &locking, nil
+}
+
+// addRequestedEvent records the goat tx event to the validator of the unlock or
+// claim request it completes. The stored request of a reorged block is skipped,
+// it's replaced once the request is re-indexed on the canonical chain.
+func (b *GoatLockingIndexer) addRequestedEvent(requests map[uint64]*goattypes.LockingRequestRecord, read func(ethdb.KeyValueReader, uint64) *goattypes.LockingRequestRecord, id uint64, event goattypes.LockingEvent) {
+	record, ok := requests[id]
+	if !ok {
+		record = read(b.db, id)
+		if record != nil && rawdb.ReadCanonicalHash(b.db, record.BlockNumber) != record.BlockHash {
+			record = nil
+		}
+		if record == nil {
+			log.Warn("Unknown goat locking request", "kind", event.Kind, "id", id, "tx", event.TxHash)
+			return
+		}
+	}
+	b.validators[record.Validator] = append(b.validators[record.Validator], event)
+}
+
+// Commit implements core.ChainIndexerBackend, writing the locking events of the
+// section to the database. The events of a re-processed section are replaced, the
+// stale events left by a reorg are skipped by the readers as they're not canonical.
+func (b *GoatLockingIndexer) Commit() error {
+	batch := b.db.NewBatch()
+	for validator, events := range b.validators {
+		rawdb.WriteGoatValidatorEvents(batch, validator, b.section, events)
+	}
+	if len(b.params) != 0 {
+		rawdb.WriteGoatLockingParamsEvents(batch, b.section, b.params)
+	}
+	for id, record := range b.unlocks {
+		rawdb.WriteGoatUnlockRequest(batch, id, record)
+	}
+	for id, record := range b.claims {
+		rawdb.WriteGoatClaimRequest(batch, id, record)
+	}
+	log.Debug("Indexed goat locking events", "section", b.section, "validators", len(b.validators), "params", len(b.params))
+	return batch.Write()
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (b *GoatLockingIndexer) Prune(threshold uint64) error {
+	return nil
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestGoatLockingIndexer(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		to        = common.HexToAddress("0x4a284d2835a3497e08b8b7fb30459a1c8229553d")
+		validator = common.HexToAddress("0x8945a1288dc78a6d8952a92c77aee6730b414778")
+
+		lockLog = &types.Log{
+			Address: goattypes.LockingContract,
+			Topics:  []common.Hash{goattypes.LockEventTopic},
+			Data:    hexutil.MustDecode("0x0000000000000000000000008945a1288dc78a6d8952a92c77aee6730b4147780000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000a"),
+		}
+		unlockLog = &types.Log{
+			Address: goattypes.LockingContract,
+			Topics:  []common.Hash{goattypes.UnlockEventTopic},
+			Data:    hexutil.MustDecode("0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000008945a1288dc78a6d8952a92c77aee6730b4147780000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc40000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000a"),
+		}
+		grantLog = &types.Log{
+			Address: goattypes.LockingContract,
+			Topics:  []common.Hash{goattypes.GrantEventTopic},
+			Data:    hexutil.MustDecode("0x000000000000000000000000000000000000000000000000000000000000000a"),
+		}
+	)
+
+	// block 1 locks and requests the unlock 0, block 2 completes it in the next section
+	var (
+		lock     = types.NewTx(&types.LegacyTx{Nonce: 0, To: &to, Gas: 21000, GasPrice: big.NewInt(1)})
+		complete = types.NewTx(types.NewGoatTx(goattypes.LockingModule, goattypes.LockingCompleteUnlockAction, 0,
+			&goattypes.CompleteUnlockTx{Id: 0, Recipient: to, Token: common.Address{}, Amount: big.NewInt(10)}))
+	)
+	blocks := []struct {
+		txs      types.Transactions
+		receipts types.Receipts
+	}{
+		{
+			types.Transactions{lock},
+			types.Receipts{{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{lockLog, unlockLog, grantLog}}},
+		},
+		{
+			types.Transactions{complete},
+			types.Receipts{{Status: types.ReceiptStatusSuccessful}},
+		},
+	}
+	var headers []*types.Header
+	for i, b := range blocks {
+		header := &types.Header{Number: big.NewInt(int64(i + 1)), Difficulty: common.Big0}
+		block := types.NewBlock(header, &types.Body{Transactions: b.txs}, b.receipts, trie.NewStackTrie(nil))
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), b.receipts)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		headers = append(headers, block.Header())
+	}
+
+	indexer := &GoatLockingIndexer{db: db, size: 1}
+	for i, header := range headers {
+		if err := indexer.Reset(context.Background(), uint64(i+1), common.Hash{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := indexer.Process(context.Background(), header); err != nil {
+			t.Fatal(err)
+		}
+		if err := indexer.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	kinds := func(events []goattypes.LockingEvent) []goattypes.LockingEventKind {
+		var res []goattypes.LockingEventKind
+		for _, event := range events {
+			res = append(res, event.Kind)
+		}
+		return res
+	}
+	want := []goattypes.LockingEventKind{goattypes.LockingLockEvent, goattypes.LockingUnlockEvent, goattypes.LockingCompleteUnlockEvent}
+	if got := kinds(rawdb.ReadGoatValidatorEvents(db, validator)); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected validator events: have %v, want %v", got, want)
+	}
+	if got := kinds(rawdb.ReadGoatLockingParamsEvents(db)); !reflect.DeepEqual(got, []goattypes.LockingEventKind{goattypes.LockingGrantEvent}) {
+		t.Errorf("unexpected locking params events: %v", got)
+	}
+	if got := rawdb.ReadGoatValidators(db); !reflect.DeepEqual(got, []common.Address{validator}) {
+		t.Errorf("unexpected validators: %v", got)
+	}
+
+	// The re-processed section replaces its own events only
+	if err := indexer.Reset(context.Background(), 1, common.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := indexer.Process(context.Background(), headers[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := indexer.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got := kinds(rawdb.ReadGoatValidatorEvents(db, validator)); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected validator events of the re-processed section: have %v, want %v", got, want)
+	}
+	if got := rawdb.ReadGoatValidators(db); !reflect.DeepEqual(got, []common.Address{validator}) {
+		t.Errorf("unexpected validators: %v", got)
+	}
+
+	// The unlock request of a reorged block doesn't attribute the completion
+	rawdb.WriteCanonicalHash(db, common.Hash{0x01}, 1)
+	if err := indexer.Reset(context.Background(), 2, common.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := indexer.Process(context.Background(), headers[1]); err != nil {
+		t.Fatal(err)
+	}
+	if len(indexer.validators) != 0 {
+		t.Errorf("unexpected validator events of the reorged unlock request: %v", indexer.validators)
+	}
+}
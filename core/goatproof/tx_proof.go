@@ -0,0 +1,80 @@
+package goatproof
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+)
+
+var (
+	ErrTxProofMismatch = errors.New("goat tx proof mismatch")
+	ErrTxNotProven     = errors.New("goat tx is not proven by the proof")
+)
+
+// TxProof is the merkle proof of a goat tx under the goat tx root of the header
+// extra, which could be verified with the block header only.
+type TxProof struct {
+	BlockHash   common.Hash     `json:"blockHash"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	TxRoot      common.Hash     `json:"txRoot"`
+	Index       hexutil.Uint64  `json:"transactionIndex"`
+	TxHash      common.Hash     `json:"transactionHash"`
+	Proof       []hexutil.Bytes `json:"proof"` // trie nodes from the root to the tx
+}
+
+// Verify checks the proof against the header extra of the block, the proven goat
+// tx is returned. The caller should make sure that the header extra is from the
+// block header with the proof block hash.
+func (p *TxProof) Verify(goat params.GoatParams, extra []byte) (*types.GoatTx, error) {
+	header, err := goattypes.DecodeHeaderExtra(goat, extra)
+	if err != nil {
+		return nil, err
+	}
+	if header.TxRoot != p.TxRoot {
+		return nil, fmt.Errorf("%w: tx root %x, header %x", ErrTxProofMismatch, p.TxRoot, header.TxRoot)
+	}
+	if uint64(p.Index) >= uint64(header.TxCount) {
+		return nil, fmt.Errorf("%w: tx index %d, goat tx count %d", ErrTxProofMismatch, p.Index, header.TxCount)
+	}
+	proof := make(trienode.ProofList, len(p.Proof))
+	for i, node := range p.Proof {
+		proof[i] = rlp.RawValue(node)
+	}
+	tx, err := VerifyTxProof(p.TxRoot, uint64(p.Index), proof)
+	if err != nil {
+		return nil, err
+	}
+	if hash := tx.Hash(); hash != p.TxHash {
+		return nil, fmt.Errorf("%w: tx hash %x, proven %x", ErrTxProofMismatch, p.TxHash, hash)
+	}
+	goatTx := tx.AsGoatTx()
+	if goatTx == nil {
+		return nil, fmt.Errorf("%w: tx type %d", ErrTxProofMismatch, tx.Type())
+	}
+	return goatTx, nil
+}
+
+// VerifyTxProof checks the merkle proof of the tx at the index against the tx
+// root, the proven tx is returned.
+func VerifyTxProof(root common.Hash, index uint64, proof trienode.ProofList) (*types.Transaction, error) {
+	enc, err := trie.VerifyProof(root, rlp.AppendUint64(nil, index), proof.Set())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTxNotProven, err)
+	}
+	if len(enc) == 0 {
+		return nil, ErrTxNotProven
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(enc); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
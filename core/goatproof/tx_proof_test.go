@@ -0,0 +1,52 @@
+package goatproof
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+func TestVerifyTxProof(t *testing.T) {
+	var txs types.Transactions
+	for i := 0; i < 3; i++ {
+		deposit := &goattypes.DepositTx{Txid: common.BigToHash(big.NewInt(int64(i + 1))), TxOut: uint32(i), Target: common.HexToAddress("0xdeadbeef"), Amount: big.NewInt(1e18)}
+		txs = append(txs, types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, uint64(i), deposit)))
+	}
+	tr := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	root := types.DeriveSha(txs, tr)
+
+	var proof trienode.ProofList
+	if err := tr.Prove(rlp.AppendUint64(nil, 1), &proof); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := VerifyTxProof(root, 1, proof)
+	if err != nil {
+		t.Fatalf("failed to verify the proof: %v", err)
+	}
+	if tx.Hash() != txs[1].Hash() {
+		t.Errorf("proven tx mismatch: have %x, want %x", tx.Hash(), txs[1].Hash())
+	}
+	if have, want := tx.AsGoatTx().Inner(), txs[1].AsGoatTx().Inner(); !reflect.DeepEqual(have, want) {
+		t.Errorf("proven goat tx mismatch: have %+v, want %+v", have, want)
+	}
+
+	if _, err := VerifyTxProof(root, 3, proof); !errors.Is(err, ErrTxNotProven) {
+		t.Errorf("expected error for the wrong index, got %v", err)
+	}
+	if _, err := VerifyTxProof(common.Hash{}, 1, proof); !errors.Is(err, ErrTxNotProven) {
+		t.Errorf("expected error for the wrong root, got %v", err)
+	}
+	if _, err := VerifyTxProof(root, 1, proof[:len(proof)-1]); !errors.Is(err, ErrTxNotProven) {
+		t.Errorf("expected error for the incomplete proof, got %v", err)
+	}
+}
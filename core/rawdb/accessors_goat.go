@@ -0,0 +1,249 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func readGoatBridgeEvents(db ethdb.KeyValueReader, key []byte) []goattypes.BridgeEvent {
+	data, _ := db.Get(key)
+	if len(data) == 0 {
+		return nil
+	}
+	var events []goattypes.BridgeEvent
+	if err := rlp.DecodeBytes(data, &events); err != nil {
+		log.Error("Invalid goat bridge events RLP", "key", key, "err", err)
+		return nil
+	}
+	return events
+}
+
+func writeGoatBridgeEvents(db ethdb.KeyValueWriter, key []byte, events []goattypes.BridgeEvent) {
+	data, err := rlp.EncodeToBytes(events)
+	if err != nil {
+		log.Crit("Failed to encode goat bridge events", "err", err)
+	}
+	if err := db.Put(key, data); err != nil {
+		log.Crit("Failed to store goat bridge events", "err", err)
+	}
+}
+
+// ReadGoatWithdrawalEvents retrieves the indexed state changes of a bridge withdrawal.
+func ReadGoatWithdrawalEvents(db ethdb.KeyValueReader, id uint64) []goattypes.BridgeEvent {
+	return readGoatBridgeEvents(db, goatWithdrawalKey(id))
+}
+
+// WriteGoatWithdrawalEvents stores the indexed state changes of a bridge withdrawal.
+func WriteGoatWithdrawalEvents(db ethdb.KeyValueWriter, id uint64, events []goattypes.BridgeEvent) {
+	writeGoatBridgeEvents(db, goatWithdrawalKey(id), events)
+}
+
+// ReadGoatDepositEvents retrieves the indexed state changes of a bridge deposit.
+func ReadGoatDepositEvents(db ethdb.KeyValueReader, txid common.Hash, txout uint32) []goattypes.BridgeEvent {
+	return readGoatBridgeEvents(db, goatDepositKey(txid, txout))
+}
+
+// WriteGoatDepositEvents stores the indexed state changes of a bridge deposit.
+func WriteGoatDepositEvents(db ethdb.KeyValueWriter, txid common.Hash, txout uint32, events []goattypes.BridgeEvent) {
+	writeGoatBridgeEvents(db, goatDepositKey(txid, txout), events)
+}
+
+// ReadGoatFeeRecord retrieves the gas fee distribution of a block.
+func ReadGoatFeeRecord(db ethdb.KeyValueReader, hash common.Hash, number uint64) *goattypes.FeeRecord {
+	data, _ := db.Get(goatFeeRecordKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	record := new(goattypes.FeeRecord)
+	if err := rlp.DecodeBytes(data, record); err != nil {
+		log.Error("Invalid goat fee record RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return record
+}
+
+// WriteGoatFeeRecord stores the gas fee distribution of a block.
+func WriteGoatFeeRecord(db ethdb.KeyValueWriter, hash common.Hash, number uint64, record *goattypes.FeeRecord) {
+	data, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		log.Crit("Failed to encode goat fee record", "err", err)
+	}
+	if err := db.Put(goatFeeRecordKey(number, hash), data); err != nil {
+		log.Crit("Failed to store goat fee record", "err", err)
+	}
+}
+
+// DeleteGoatFeeRecord removes the gas fee distribution of a block.
+func DeleteGoatFeeRecord(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(goatFeeRecordKey(number, hash)); err != nil {
+		log.Crit("Failed to delete goat fee record", "err", err)
+	}
+}
+
+// ReadGoatBtcBlock retrieves the indexed bitcoin block at the given height.
+func ReadGoatBtcBlock(db ethdb.KeyValueReader, height uint64) *goattypes.BtcBlockRecord {
+	data, _ := db.Get(goatBtcBlockKey(height))
+	if len(data) == 0 {
+		return nil
+	}
+	record := new(goattypes.BtcBlockRecord)
+	if err := rlp.DecodeBytes(data, record); err != nil {
+		log.Error("Invalid goat btc block RLP", "height", height, "err", err)
+		return nil
+	}
+	return record
+}
+
+// WriteGoatBtcBlock stores the indexed bitcoin block at the given height.
+func WriteGoatBtcBlock(db ethdb.KeyValueWriter, height uint64, record *goattypes.BtcBlockRecord) {
+	data, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		log.Crit("Failed to encode goat btc block", "err", err)
+	}
+	if err := db.Put(goatBtcBlockKey(height), data); err != nil {
+		log.Crit("Failed to store goat btc block", "err", err)
+	}
+}
+
+// ReadGoatBtcTip retrieves the height of the latest indexed bitcoin block.
+func ReadGoatBtcTip(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(goatBtcTipKey)
+	if len(data) != 8 {
+		return nil
+	}
+	height := binary.BigEndian.Uint64(data)
+	return &height
+}
+
+// WriteGoatBtcTip stores the height of the latest indexed bitcoin block.
+func WriteGoatBtcTip(db ethdb.KeyValueWriter, height uint64) {
+	if err := db.Put(goatBtcTipKey, encodeBlockNumber(height)); err != nil {
+		log.Crit("Failed to store goat btc tip", "err", err)
+	}
+}
+
+func decodeGoatLockingEvents(key, data []byte) []goattypes.LockingEvent {
+	var events []goattypes.LockingEvent
+	if err := rlp.DecodeBytes(data, &events); err != nil {
+		log.Error("Invalid goat locking events RLP", "key", common.Bytes2Hex(key), "err", err)
+		return nil
+	}
+	return events
+}
+
+// readGoatLockingEvents retrieves the locking events of all the sections under the
+// prefix, which are ordered by the section.
+func readGoatLockingEvents(db ethdb.Iteratee, prefix []byte) []goattypes.LockingEvent {
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var events []goattypes.LockingEvent
+	for it.Next() {
+		if key := it.Key(); len(key) == len(prefix)+8 {
+			events = append(events, decodeGoatLockingEvents(key, it.Value())...)
+		}
+	}
+	return events
+}
+
+func writeGoatLockingEvents(db ethdb.KeyValueWriter, key []byte, events []goattypes.LockingEvent) {
+	data, err := rlp.EncodeToBytes(events)
+	if err != nil {
+		log.Crit("Failed to encode goat locking events", "err", err)
+	}
+	if err := db.Put(key, data); err != nil {
+		log.Crit("Failed to store goat locking events", "err", err)
+	}
+}
+
+// ReadGoatValidatorEvents retrieves the indexed locking events of a validator.
+func ReadGoatValidatorEvents(db ethdb.Iteratee, validator common.Address) []goattypes.LockingEvent {
+	return readGoatLockingEvents(db, append(goatValidatorPrefix, validator.Bytes()...))
+}
+
+// WriteGoatValidatorEvents stores the locking events of a validator indexed in the
+// given section, replacing the events of the section stored before.
+func WriteGoatValidatorEvents(db ethdb.KeyValueWriter, validator common.Address, section uint64, events []goattypes.LockingEvent) {
+	writeGoatLockingEvents(db, goatValidatorKey(validator, section), events)
+}
+
+// ReadGoatValidators retrieves the addresses of the indexed validators.
+func ReadGoatValidators(db ethdb.Iteratee) []common.Address {
+	it := db.NewIterator(goatValidatorPrefix, nil)
+	defer it.Release()
+
+	var validators []common.Address
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(goatValidatorPrefix)+common.AddressLength+8 {
+			continue
+		}
+		// The sections of a validator are iterated in a row
+		validator := common.BytesToAddress(key[len(goatValidatorPrefix) : len(goatValidatorPrefix)+common.AddressLength])
+		if len(validators) == 0 || validators[len(validators)-1] != validator {
+			validators = append(validators, validator)
+		}
+	}
+	return validators
+}
+
+// ReadGoatLockingParamsEvents retrieves the indexed locking events of the grants
+// and the token updates.
+func ReadGoatLockingParamsEvents(db ethdb.Iteratee) []goattypes.LockingEvent {
+	return readGoatLockingEvents(db, goatLockingParamsPrefix)
+}
+
+// WriteGoatLockingParamsEvents stores the locking events of the grants and the token
+// updates indexed in the given section, replacing the events of the section stored
+// before.
+func WriteGoatLockingParamsEvents(db ethdb.KeyValueWriter, section uint64, events []goattypes.LockingEvent) {
+	writeGoatLockingEvents(db, goatLockingParamsKey(section), events)
+}
+
+func readGoatLockingRequest(db ethdb.KeyValueReader, key []byte) *goattypes.LockingRequestRecord {
+	data, _ := db.Get(key)
+	if len(data) == 0 {
+		return nil
+	}
+	record := new(goattypes.LockingRequestRecord)
+	if err := rlp.DecodeBytes(data, record); err != nil {
+		log.Error("Invalid goat locking request RLP", "key", common.Bytes2Hex(key), "err", err)
+		return nil
+	}
+	return record
+}
+
+func writeGoatLockingRequest(db ethdb.KeyValueWriter, key []byte, record *goattypes.LockingRequestRecord) {
+	data, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		log.Crit("Failed to encode goat locking request", "err", err)
+	}
+	if err := db.Put(key, data); err != nil {
+		log.Crit("Failed to store goat locking request", "err", err)
+	}
+}
+
+// ReadGoatUnlockRequest retrieves the validator record of the indexed unlock request.
+func ReadGoatUnlockRequest(db ethdb.KeyValueReader, id uint64) *goattypes.LockingRequestRecord {
+	return readGoatLockingRequest(db, goatUnlockKey(id))
+}
+
+// WriteGoatUnlockRequest stores the validator record of the indexed unlock request.
+func WriteGoatUnlockRequest(db ethdb.KeyValueWriter, id uint64, record *goattypes.LockingRequestRecord) {
+	writeGoatLockingRequest(db, goatUnlockKey(id), record)
+}
+
+// ReadGoatClaimRequest retrieves the validator record of the indexed claim request.
+func ReadGoatClaimRequest(db ethdb.KeyValueReader, id uint64) *goattypes.LockingRequestRecord {
+	return readGoatLockingRequest(db, goatClaimKey(id))
+}
+
+// WriteGoatClaimRequest stores the validator record of the indexed claim request.
+func WriteGoatClaimRequest(db ethdb.KeyValueWriter, id uint64, record *goattypes.LockingRequestRecord) {
+	writeGoatLockingRequest(db, goatClaimKey(id), record)
+}
@@ -141,6 +141,19 @@ var (
 
 	CliqueSnapshotPrefix = []byte("clique-")
 
+	GoatBridgeIndexPrefix = []byte("iG")
+	goatWithdrawalPrefix  = []byte("goat-bw-") // goatWithdrawalPrefix + id (uint64 big endian) -> bridge events
+	goatDepositPrefix     = []byte("goat-bd-") // goatDepositPrefix + txid + txout (uint32 big endian) -> bridge events
+	goatFeeRecordPrefix   = []byte("goat-f-")  // goatFeeRecordPrefix + num (uint64 big endian) + hash -> fee record
+	goatBtcBlockPrefix    = []byte("goat-bb-") // goatBtcBlockPrefix + height (uint64 big endian) -> btc block record
+	goatBtcTipKey         = []byte("goat-bt")  // height of the latest indexed btc block
+
+	GoatLockingIndexPrefix  = []byte("iL")
+	goatValidatorPrefix     = []byte("goat-lv-") // goatValidatorPrefix + validator address + section (uint64 big endian) -> locking events
+	goatUnlockPrefix        = []byte("goat-lu-") // goatUnlockPrefix + id (uint64 big endian) -> locking request record
+	goatClaimPrefix         = []byte("goat-lc-") // goatClaimPrefix + id (uint64 big endian) -> locking request record
+	goatLockingParamsPrefix = []byte("goat-lp-") // goatLockingParamsPrefix + section (uint64 big endian) -> locking events of the grants and the token updates
+
 	BestUpdateKey         = []byte("update-")    // bigEndian64(syncPeriod) -> RLP(types.LightClientUpdate)  (nextCommittee only referenced by root hash)
 	FixedCommitteeRootKey = []byte("fixedRoot-") // bigEndian64(syncPeriod) -> committee root hash
 	SyncCommitteeKey      = []byte("committee-") // bigEndian64(syncPeriod) -> serialized committee
@@ -164,6 +177,48 @@ func encodeBlockNumber(number uint64) []byte {
 	return enc
 }
 
+// goatWithdrawalKey = goatWithdrawalPrefix + id (uint64 big endian)
+func goatWithdrawalKey(id uint64) []byte {
+	return append(goatWithdrawalPrefix, encodeBlockNumber(id)...)
+}
+
+// goatDepositKey = goatDepositPrefix + txid + txout (uint32 big endian)
+func goatDepositKey(txid common.Hash, txout uint32) []byte {
+	key := append(append(goatDepositPrefix, txid.Bytes()...), 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(key[len(key)-4:], txout)
+	return key
+}
+
+// goatFeeRecordKey = goatFeeRecordPrefix + num (uint64 big endian) + hash
+func goatFeeRecordKey(number uint64, hash common.Hash) []byte {
+	return append(append(goatFeeRecordPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// goatBtcBlockKey = goatBtcBlockPrefix + height (uint64 big endian)
+func goatBtcBlockKey(height uint64) []byte {
+	return append(goatBtcBlockPrefix, encodeBlockNumber(height)...)
+}
+
+// goatValidatorKey = goatValidatorPrefix + validator address + section (uint64 big endian)
+func goatValidatorKey(validator common.Address, section uint64) []byte {
+	return append(append(goatValidatorPrefix, validator.Bytes()...), encodeBlockNumber(section)...)
+}
+
+// goatLockingParamsKey = goatLockingParamsPrefix + section (uint64 big endian)
+func goatLockingParamsKey(section uint64) []byte {
+	return append(goatLockingParamsPrefix, encodeBlockNumber(section)...)
+}
+
+// goatUnlockKey = goatUnlockPrefix + id (uint64 big endian)
+func goatUnlockKey(id uint64) []byte {
+	return append(goatUnlockPrefix, encodeBlockNumber(id)...)
+}
+
+// goatClaimKey = goatClaimPrefix + id (uint64 big endian)
+func goatClaimKey(id uint64) []byte {
+	return append(goatClaimPrefix, encodeBlockNumber(id)...)
+}
+
 // headerKeyPrefix = headerPrefix + num (uint64 big endian)
 func headerKeyPrefix(number uint64) []byte {
 	return append(headerPrefix, encodeBlockNumber(number)...)
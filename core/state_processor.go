@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
@@ -63,8 +64,8 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		allLogs     []*types.Log
 		gp          = new(GasPool).AddGas(block.GasLimit())
 
-		// gas reward to validators and delegators
-		gasReward = new(big.Int)
+		// tips of the non-goat txs, they're distributed with the base fees
+		gasTips = new(big.Int)
 	)
 
 	// Mutate the block and state according to any hard-fork specs
@@ -105,7 +106,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		if receipt.GasUsed > 0 { // non-goatTx case
 			tipFee := new(big.Int).SetUint64(receipt.GasUsed)
 			tipFee.Mul(tipFee, tx.EffectiveGasTipValue(context.BaseFee))
-			gasReward.Add(gasReward, tipFee)
+			gasTips.Add(gasTips, tipFee)
 		}
 	}
 	var tracingStateDB = vm.StateDB(statedb)
@@ -113,7 +114,10 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		tracingStateDB = state.NewHookedState(statedb, hooks)
 	}
 	// Read requests if Prague is enabled.
-	var requests [][]byte
+	var (
+		requests [][]byte
+		goatFees *goattypes.FeeRecord
+	)
 	if p.config.Goat != nil {
 		burntFees := new(big.Int)
 		if context.BaseFee != nil && header.GasUsed > 0 {
@@ -123,9 +127,8 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 			blobUsed := new(big.Int).SetUint64(*gasUsed)
 			burntFees.Add(burntFees, blobUsed.Mul(blobUsed, context.BlobBaseFee))
 		}
-		gasReward.Add(gasReward, burntFees)
-		reward := ProcessGoatGasFee(statedb, gasReward)
-		goatRequests, err := ProcessGoatRequests(block.NumberU64(), reward, allLogs)
+		goatFees = ProcessGoatGasFee(p.config.Goat.Params(header.Time), tracingStateDB, burntFees, gasTips)
+		goatRequests, err := ProcessGoatRequests(block.NumberU64(), goatFees.Revenue, allLogs)
 		if err != nil {
 			return nil, err
 		}
@@ -153,6 +156,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		Requests: requests,
 		Logs:     allLogs,
 		GasUsed:  *usedGas,
+		GoatFees: goatFees,
 	}, nil
 }
 
@@ -250,7 +254,8 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 // ProcessBeaconBlockRoot applies the EIP-4788 system call to the beacon block root
 // contract. This method is exported to be used in tests.
 func ProcessBeaconBlockRoot(beaconRoot common.Hash, vmenv *vm.EVM, statedb vm.StateDB) {
-	if vmenv.ChainConfig().Goat != nil { // reenable it when we have a correct beacon root
+	// the goat consensus layer provides a verifiable root since the goat fork enabling it
+	if goat := vmenv.ChainConfig().Goat; goat != nil && !goat.Params(vmenv.Context.Time).BeaconRoot {
 		return
 	}
 
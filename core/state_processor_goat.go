@@ -1,42 +1,152 @@
 package core
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
 )
 
 var (
-	gfBasePoint    = big.NewInt(200)
-	gfMaxBasePoint = big.NewInt(1e4)
-)
+	gfMaxBasePoint = big.NewInt(params.GoatMaxBasisPoints)
 
-func ProcessGoatGasFee(statedb *state.StateDB, gasFees *big.Int) *big.Int {
-	if gasFees.BitLen() == 0 {
-		return new(big.Int)
-	}
+	goatInvalidFeeSharesMeter = metrics.NewRegisteredMeter("chain/goat/feeshares/invalid", nil)
+)
 
-	// foundation tax 2%
-	tax := new(big.Int).Mul(gasFees, gfBasePoint)
-	tax.Div(tax, gfMaxBasePoint)
+// ProcessGoatGasFee pays the foundation tax from the gas fees and adds the remaining
+// to the locking contract, it returns the fee record of the block. After the fee split
+// fork, the fees are paid to the recipients of the fee shares stored in the foundation
+// contract instead of the foundation tax.
+func ProcessGoatGasFee(goat params.GoatParams, statedb vm.StateDB, burnt, tips *big.Int) *goattypes.FeeRecord {
+	record := &goattypes.FeeRecord{Burnt: burnt, Tips: tips}
 
-	if tax.BitLen() != 0 {
-		f, _ := uint256.FromBig(tax)
-		statedb.AddBalance(goattypes.GoatFoundationContract, f, tracing.BalanceIncreaseRewardTransactionFee)
+	// foundation tax or fee shares
+	shares, err := goatFeeShares(goat, statedb)
+	if err != nil {
+		log.Error("Invalid goat fee shares, paying the foundation tax instead", "err", err)
+		goatInvalidFeeSharesMeter.Mark(1)
+		record.InvalidFeeShares = true
+	}
+	payouts, tax, gas := splitGoatGasFee(shares, new(big.Int).Add(burnt, tips))
+	for _, payout := range payouts {
+		if payout.Amount.BitLen() != 0 {
+			f, _ := uint256.FromBig(payout.Amount)
+			statedb.AddBalance(payout.Recipient, f, tracing.BalanceGoatGasTax)
+		}
 	}
 
 	// add gas revenue to locking contract
 	// if the validator withdraws the gas reward, we will subtract it from locking contract then
-	gas := new(big.Int).Sub(gasFees, tax)
 	if gas.BitLen() != 0 {
 		f, _ := uint256.FromBig(gas)
-		statedb.AddBalance(goattypes.LockingContract, f, tracing.BalanceIncreaseRewardTransactionFee)
+		statedb.AddBalance(goattypes.LockingContract, f, tracing.BalanceGoatGasRevenue)
+	}
+
+	record.Tax, record.Revenue = tax, gas
+	if goat.FeeSplit {
+		record.Payouts = payouts
+	}
+	return record
+}
+
+// goatFeeShares returns the recipients of the gas fees other than the locking contract.
+// The foundation tax is used if the fee shares are not activated, not set or invalid,
+// the error is returned along with the foundation tax if they're invalid.
+func goatFeeShares(goat params.GoatParams, state goattypes.StateReader) ([]goattypes.FeeShare, error) {
+	foundation := []goattypes.FeeShare{{Recipient: goattypes.GoatFoundationContract, Weight: goat.FoundationTax}}
+	if !goat.FeeSplit {
+		return foundation, nil
+	}
+	shares, err := goattypes.ReadFeeShares(state)
+	if err != nil {
+		return foundation, err
+	}
+	if len(shares) == 0 {
+		return foundation, nil
+	}
+	return shares, nil
+}
+
+// splitGoatGasFee splits the gas fees into the payouts of the fee shares and the gas
+// revenue, the rounding remainder goes to the gas revenue.
+func splitGoatGasFee(shares []goattypes.FeeShare, gasFees *big.Int) (payouts []*goattypes.FeePayout, tax *big.Int, gas *big.Int) {
+	tax = new(big.Int)
+	for _, share := range shares {
+		amount := new(big.Int).Mul(gasFees, new(big.Int).SetUint64(share.Weight))
+		amount.Div(amount, gfMaxBasePoint)
+		payouts = append(payouts, &goattypes.FeePayout{Recipient: share.Recipient, Amount: amount})
+		tax.Add(tax, amount)
+	}
+	return payouts, tax, new(big.Int).Sub(gasFees, tax)
+}
+
+// DeriveGoatFeeRecord re-derives the gas fee distribution of a block imported with
+// its receipts, which is not processed locally. The state after the block is required
+// to read the fee shares after the fee split fork, it could be nil before the fork.
+func DeriveGoatFeeRecord(config *params.ChainConfig, block *types.Block, receipts types.Receipts, state goattypes.StateReader) (*goattypes.FeeRecord, error) {
+	if config.Goat == nil {
+		return nil, errors.New("not a goat chain")
+	}
+	goat := config.Goat.Params(block.Time())
+	if goat.FeeSplit && state == nil {
+		return nil, errors.New("missing state to read the goat fee shares")
+	}
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return nil, fmt.Errorf("receipt count mismatch: have %d, want %d", len(receipts), len(txs))
 	}
-	return gas
+
+	header := block.Header()
+	record := &goattypes.FeeRecord{Burnt: new(big.Int), Tips: new(big.Int)}
+	if header.BaseFee != nil && header.GasUsed > 0 {
+		record.Burnt.Mul(header.BaseFee, new(big.Int).SetUint64(header.GasUsed))
+	}
+	if header.ExcessBlobGas != nil && header.BlobGasUsed != nil && *header.BlobGasUsed > 0 {
+		blobUsed := new(big.Int).SetUint64(*header.BlobGasUsed)
+		record.Burnt.Add(record.Burnt, blobUsed.Mul(blobUsed, eip4844.CalcBlobFee(*header.ExcessBlobGas)))
+	}
+	// The gas used is calculated from the consensus fields, so that the fees
+	// could be derived from the raw receipts as well
+	var cumulativeGasUsed uint64
+	for i, receipt := range receipts {
+		gasUsed := receipt.CumulativeGasUsed - cumulativeGasUsed
+		cumulativeGasUsed = receipt.CumulativeGasUsed
+		if gasUsed == 0 { // It's the goat tx
+			continue
+		}
+		tipFee := new(big.Int).SetUint64(gasUsed)
+		record.Tips.Add(record.Tips, tipFee.Mul(tipFee, txs[i].EffectiveGasTipValue(header.BaseFee)))
+	}
+	// The fee distribution only changes the balances, so the fee shares in the
+	// state after the block are the ones used to process the block
+	shares, err := goatFeeShares(goat, state)
+	record.InvalidFeeShares = err != nil
+	payouts, tax, revenue := splitGoatGasFee(shares, new(big.Int).Add(record.Burnt, record.Tips))
+	record.Tax, record.Revenue = tax, revenue
+	if goat.FeeSplit {
+		record.Payouts = payouts
+	}
+	return record, nil
+}
+
+// DeriveGoatRequests re-derives the goat requests of a processed block from its
+// receipts and fee record.
+func DeriveGoatRequests(block *types.Block, receipts types.Receipts, record *goattypes.FeeRecord) ([][]byte, error) {
+	var allLogs []*types.Log
+	for _, receipt := range receipts {
+		allLogs = append(allLogs, receipt.Logs...)
+	}
+	return ProcessGoatRequests(block.NumberU64(), record.Revenue, allLogs)
 }
 
 // ProcessGoatRequests processes goat requests
@@ -150,3 +260,40 @@ func ProcessGoatRequests(height uint64, reward *big.Int, allLogs []*types.Log) (
 	requests = append(requests, relayerRequests.Encode()...)
 	return requests, nil
 }
+
+// NewGoatHeaderExtra returns the header extra committing the goat txs at the head
+// of the block. Since the V1 layout, it commits the number of the goat requests and
+// the latest bitcoin block height in the state after the block as well.
+func NewGoatHeaderExtra(goat params.GoatParams, goatTxs types.Transactions, requests [][]byte, statedb vm.StateDB) ([]byte, error) {
+	if len(goatTxs) > goat.TxLimitPerBlock {
+		return nil, fmt.Errorf("too many goat txs: have %d, max %d", len(goatTxs), goat.TxLimitPerBlock)
+	}
+	extra := &goattypes.HeaderExtra{
+		Version: goat.HeaderExtraVersion,
+		TxCount: uint8(len(goatTxs)),
+		TxRoot:  types.DeriveSha(goatTxs, trie.NewStackTrie(nil)),
+	}
+	if extra.Version >= goattypes.HeaderExtraV1 {
+		count, err := goatRequestsCount(requests)
+		if err != nil {
+			return nil, err
+		}
+		extra.RequestsCount = count
+		extra.BtcHeight = goatBtcHeight(statedb)
+	}
+	return extra.Encode(goat)
+}
+
+// goatRequestsCount returns the number of the typed goat requests
+func goatRequestsCount(requests [][]byte) (uint32, error) {
+	reqs, err := goattypes.NewRequests(requests)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(reqs.Len()), nil
+}
+
+// goatBtcHeight returns the latest bitcoin block height of the bitcoin contract
+func goatBtcHeight(statedb vm.StateDB) uint64 {
+	return statedb.GetState(goattypes.BitcoinContract, goattypes.BitcoinLatestHeightSlot).Big().Uint64()
+}
@@ -3,12 +3,14 @@ package core
 import (
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -262,6 +264,7 @@ func TestProcessGoatGasFee(t *testing.T) {
 		}
 	)
 
+	setupGoatTestGenesis(gspec)
 	signer := types.LatestSigner(gspec.Config)
 
 	to := common.HexToAddress("0x4a284d2835a3497e08b8b7fb30459a1c8229553d")
@@ -334,3 +337,359 @@ func TestProcessGoatGasFee(t *testing.T) {
 		t.Errorf("RequestsHash expected %x got %x", requestsHash, *gotRequestshash)
 	}
 }
+
+func TestProcessGoatGasFeeFork(t *testing.T) {
+	var (
+		engine = beacon.NewFaker()
+
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		funds  = new(big.Int).Mul(big.NewInt(1e6), big.NewInt(params.Ether))
+		config = *params.AllGoatDebugChainConfig
+		gspec  = &Genesis{
+			Config: &config,
+			Alloc:  types.GenesisAlloc{addr: {Balance: funds}},
+		}
+	)
+
+	// the block 1 is before the fork and the block 2 is after that
+	forked := params.GoatParamsV0
+	forked.FoundationTax = 1000
+	forked.HeaderExtraLength = 40
+	config.Goat = &params.GoatConfig{Forks: []*params.GoatFork{{Name: "test", Time: 15, GoatParams: forked}}}
+
+	setupGoatTestGenesis(gspec)
+	signer := types.LatestSigner(gspec.Config)
+	to := common.HexToAddress("0x4a284d2835a3497e08b8b7fb30459a1c8229553d")
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &to,
+			Gas:      21000,
+			GasPrice: new(big.Int).SetUint64(1e9),
+			Value:    big.NewInt(0),
+		}), signer, key)
+		b.AddTx(tx)
+	})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+
+	if l := len(chain.GetBlockByNumber(1).Extra()); l != params.GoatHeaderExtraLengthV0 {
+		t.Errorf("header extra length of block 1: expected %d got %d", params.GoatHeaderExtraLengthV0, l)
+	}
+	if l := len(chain.GetBlockByNumber(2).Extra()); l != forked.HeaderExtraLength {
+		t.Errorf("header extra length of block 2: expected %d got %d", forked.HeaderExtraLength, l)
+	}
+
+	// totalFee = 1e9 * 21000 per block
+	// tax = 2% of block 1 + 10% of block 2
+	state, _ := chain.State()
+	if expected := big.NewInt(420000000000 + 2100000000000); state.GetBalance(goattypes.GoatFoundationContract).CmpBig(expected) != 0 {
+		t.Errorf("balance of goat foundation: expected %s got %s", expected, state.GetBalance(goattypes.GoatFoundationContract))
+	}
+	if expected := big.NewInt(20580000000000 + 18900000000000); state.GetBalance(goattypes.LockingContract).CmpBig(expected) != 0 {
+		t.Errorf("balance of locking contract: expected %s got %s", expected, state.GetBalance(goattypes.LockingContract))
+	}
+}
+
+// feeSharesStorage returns the foundation contract storage of the given fee shares
+func feeSharesStorage(shares ...goattypes.FeeShare) map[common.Hash]common.Hash {
+	storage := map[common.Hash]common.Hash{
+		goattypes.FoundationFeeSharesSlot: common.BigToHash(big.NewInt(int64(len(shares)))),
+	}
+	base := new(big.Int).SetBytes(crypto.Keccak256(goattypes.FoundationFeeSharesSlot[:]))
+	for i, share := range shares {
+		var value common.Hash
+		new(big.Int).SetUint64(share.Weight).FillBytes(value[:12])
+		copy(value[12:], share.Recipient[:])
+		storage[common.BigToHash(new(big.Int).Add(base, big.NewInt(int64(i))))] = value
+	}
+	return storage
+}
+
+type feeSharesState map[common.Hash]common.Hash
+
+func (s feeSharesState) GetState(addr common.Address, slot common.Hash) common.Hash {
+	if addr != goattypes.GoatFoundationContract {
+		return common.Hash{}
+	}
+	return s[slot]
+}
+
+func TestProcessGoatFeeSplit(t *testing.T) {
+	var (
+		engine = beacon.NewFaker()
+
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		funds  = new(big.Int).Mul(big.NewInt(1e6), big.NewInt(params.Ether))
+		config = *params.AllGoatDebugChainConfig
+
+		recipient1 = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		recipient2 = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		shares     = []goattypes.FeeShare{{Recipient: recipient1, Weight: 3000}, {Recipient: recipient2, Weight: 500}}
+
+		gspec = &Genesis{
+			Config: &config,
+			Alloc: types.GenesisAlloc{
+				addr:                             {Balance: funds},
+				goattypes.GoatFoundationContract: {Balance: common.Big0, Storage: feeSharesStorage(shares...)},
+			},
+		}
+	)
+
+	// the block 1 is before the fork and the block 2 is after that
+	forked := params.GoatParamsV0
+	forked.FoundationTax = 1000
+	forked.FeeSplit = true
+	config.Goat = &params.GoatConfig{Forks: []*params.GoatFork{{Name: "test", Time: 15, GoatParams: forked}}}
+
+	setupGoatTestGenesis(gspec)
+	signer := types.LatestSigner(gspec.Config)
+	to := common.HexToAddress("0x4a284d2835a3497e08b8b7fb30459a1c8229553d")
+	_, blocks, receipts := GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &to,
+			Gas:      21000,
+			GasPrice: new(big.Int).SetUint64(1e9),
+			Value:    big.NewInt(0),
+		}), signer, key)
+		b.AddTx(tx)
+	})
+
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+
+	// totalFee = 1e9 * 21000 per block
+	// block 1: 2% to the foundation
+	// block 2: 30% to the recipient 1 and 5% to the recipient 2
+	state, _ := chain.State()
+	for _, c := range []struct {
+		addr     common.Address
+		expected *big.Int
+	}{
+		{goattypes.GoatFoundationContract, big.NewInt(420000000000)},
+		{recipient1, big.NewInt(6300000000000)},
+		{recipient2, big.NewInt(1050000000000)},
+		{goattypes.LockingContract, big.NewInt(20580000000000 + 13650000000000)},
+	} {
+		if got := state.GetBalance(c.addr); got.CmpBig(c.expected) != 0 {
+			t.Errorf("balance of %x: expected %s got %s", c.addr, c.expected, got)
+		}
+	}
+
+	block := chain.GetBlockByNumber(2)
+	record := rawdb.ReadGoatFeeRecord(db, block.Hash(), 2)
+	if record == nil {
+		t.Fatal("fee record of block 2 not found")
+	}
+	payouts := []*goattypes.FeePayout{
+		{Recipient: recipient1, Amount: big.NewInt(6300000000000)},
+		{Recipient: recipient2, Amount: big.NewInt(1050000000000)},
+	}
+	if !reflect.DeepEqual(record.Payouts, payouts) {
+		t.Errorf("fee payouts: expected %v got %v", payouts, record.Payouts)
+	}
+	if expected := big.NewInt(7350000000000); record.Tax.Cmp(expected) != 0 {
+		t.Errorf("fee record tax: expected %s got %s", expected, record.Tax)
+	}
+	if record := rawdb.ReadGoatFeeRecord(db, blocks[0].Hash(), 1); record == nil || record.Payouts != nil {
+		t.Errorf("fee record of block 1 should have no payouts: %v", record)
+	}
+	if _, err := DeriveGoatFeeRecord(&config, block, chain.GetReceiptsByHash(block.Hash()), nil); err == nil {
+		t.Error("expected error deriving the fee record without state")
+	}
+
+	// The blocks imported with receipts have the derived records before the fork
+	// only, the fee shares are not available without the state after the fork
+	fastDb := rawdb.NewMemoryDatabase()
+	fast, err := NewBlockChain(fastDb, nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create fast chain: %v", err)
+	}
+	defer fast.Stop()
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
+	if n, err := fast.InsertHeaderChain(headers); err != nil {
+		t.Fatalf("failed to insert header %d: %v", n, err)
+	}
+	if n, err := fast.InsertReceiptChain(blocks, receipts, 0); err != nil {
+		t.Fatalf("failed to insert receipt %d: %v", n, err)
+	}
+	if have, want := rawdb.ReadGoatFeeRecord(fastDb, blocks[0].Hash(), 1), rawdb.ReadGoatFeeRecord(db, blocks[0].Hash(), 1); !reflect.DeepEqual(have, want) {
+		t.Errorf("derived fee record of block 1: expected %v got %v", want, have)
+	}
+	if record := rawdb.ReadGoatFeeRecord(fastDb, block.Hash(), 2); record != nil {
+		t.Errorf("fee record of block 2 should not be derived without state: %v", record)
+	}
+}
+
+func TestGoatFeeSharesFallback(t *testing.T) {
+	forked := params.GoatParamsV0
+	forked.FeeSplit = true
+	foundation := []goattypes.FeeShare{{Recipient: goattypes.GoatFoundationContract, Weight: forked.FoundationTax}}
+
+	recipient := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	tooMany := make([]goattypes.FeeShare, params.GoatMaxFeeShares+1)
+	for i := range tooMany {
+		tooMany[i] = goattypes.FeeShare{Recipient: recipient, Weight: 1}
+	}
+	for name, state := range map[string]feeSharesState{
+		"empty":       feeSharesState{},
+		"too many":    feeSharesStorage(tooMany...),
+		"overflow":    feeSharesStorage(goattypes.FeeShare{Recipient: recipient, Weight: 6000}, goattypes.FeeShare{Recipient: recipient, Weight: 4001}),
+		"zero":        feeSharesStorage(goattypes.FeeShare{Weight: 100}),
+		"locking":     feeSharesStorage(goattypes.FeeShare{Recipient: goattypes.LockingContract, Weight: 100}),
+		"huge weight": feeSharesStorage(goattypes.FeeShare{Recipient: recipient, Weight: ^uint64(0)}),
+	} {
+		got, err := goatFeeShares(forked, state)
+		if !reflect.DeepEqual(got, foundation) {
+			t.Errorf("%s: expected the foundation tax, got %v", name, got)
+		}
+		if invalid := name != "empty"; invalid != (err != nil) {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+
+	full := []goattypes.FeeShare{{Recipient: recipient, Weight: params.GoatMaxBasisPoints}}
+	if got, err := goatFeeShares(forked, feeSharesState(feeSharesStorage(full...))); err != nil || !reflect.DeepEqual(got, full) {
+		t.Errorf("expected %v got %v, err %v", full, got, err)
+	}
+	if got, err := goatFeeShares(params.GoatParamsV0, feeSharesState(feeSharesStorage(full...))); err != nil || !reflect.DeepEqual(got, foundation) {
+		t.Errorf("fee shares should not be read before the fork, got %v, err %v", got, err)
+	}
+
+	// The invalid fee shares are recorded in the fee record
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	for slot, value := range feeSharesStorage(goattypes.FeeShare{Weight: 100}) {
+		statedb.SetState(goattypes.GoatFoundationContract, slot, value)
+	}
+	record := ProcessGoatGasFee(forked, statedb, big.NewInt(params.GWei), new(big.Int))
+	if !record.InvalidFeeShares || len(record.Payouts) != 1 || record.Payouts[0].Recipient != goattypes.GoatFoundationContract {
+		t.Errorf("invalid fee shares are not recorded: %+v", record)
+	}
+}
+
+func TestProcessGoatBeaconRoot(t *testing.T) {
+	var (
+		engine = beacon.NewFaker()
+		config = *params.AllGoatDebugChainConfig
+		gspec  = &Genesis{
+			Config: &config,
+			Alloc:  types.GenesisAlloc{params.BeaconRootsAddress: {Code: params.BeaconRootsCode, Nonce: 1}},
+		}
+	)
+
+	// the block 1 is before the fork and the block 2 is after that
+	forked := params.GoatParamsV0
+	forked.BeaconRoot = true
+	config.Goat = &params.GoatConfig{Forks: []*params.GoatFork{{Name: "test", Time: 15, GoatParams: forked}}}
+
+	setupGoatTestGenesis(gspec)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, nil)
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// the beacon root is required after the fork
+	header := blocks[1].Header()
+	header.ParentBeaconRoot = new(common.Hash)
+	invalid := types.NewBlockWithHeader(header).WithBody(*blocks[1].Body())
+	if _, err := chain.InsertChain(types.Blocks{blocks[0], invalid}); err == nil {
+		t.Fatal("expected error for the block without beacon root")
+	}
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+
+	state, _ := chain.State()
+	for _, block := range blocks {
+		var (
+			timeSlot = common.BigToHash(new(big.Int).SetUint64(block.Time() % 8191))
+			rootSlot = common.BigToHash(new(big.Int).SetUint64(block.Time()%8191 + 8191))
+			wantTime common.Hash
+			wantRoot common.Hash
+		)
+		if block.NumberU64() == 2 {
+			wantTime, wantRoot = common.BigToHash(new(big.Int).SetUint64(block.Time())), *block.BeaconRoot()
+		}
+		if have := state.GetState(params.BeaconRootsAddress, timeSlot); have != wantTime {
+			t.Errorf("block %d: unexpected beacon root timestamp: have %x, want %x", block.NumberU64(), have, wantTime)
+		}
+		if have := state.GetState(params.BeaconRootsAddress, rootSlot); have != wantRoot {
+			t.Errorf("block %d: unexpected beacon root: have %x, want %x", block.NumberU64(), have, wantRoot)
+		}
+	}
+	if root := *blocks[1].BeaconRoot(); root != blocks[0].Hash() {
+		t.Errorf("unexpected beacon root of block 2: have %x, want %x", root, blocks[0].Hash())
+	}
+}
+
+func TestGoatHeaderExtraV1(t *testing.T) {
+	var (
+		engine = beacon.NewFaker()
+		gspec  = DeveloperGoatGenesisBlock(30_000_000, nil)
+		config = *gspec.Config
+	)
+	v1 := params.GoatParamsV0
+	v1.HeaderExtraVersion = goattypes.HeaderExtraV1
+	v1.HeaderExtraLength = params.GoatHeaderExtraLengthV1
+	config.Goat = &params.GoatConfig{Forks: []*params.GoatFork{{Name: "v1", Time: 0, GoatParams: v1}}}
+	gspec.Config = &config
+	btcHeight := goatGenesisBtcHeight(v1, gspec.Alloc)
+	gspec.ExtraData = emptyGoatHeaderExtra(v1, btcHeight)
+	if err := gspec.VerifyGoatGenesis(); err != nil {
+		t.Fatalf("invalid genesis: %v", err)
+	}
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, nil)
+	for _, block := range blocks {
+		extra, err := goattypes.DecodeHeaderExtra(v1, block.Extra())
+		if err != nil {
+			t.Fatalf("block %d: failed to decode the header extra: %v", block.NumberU64(), err)
+		}
+		// the gas revenue request only
+		want := &goattypes.HeaderExtra{Version: goattypes.HeaderExtraV1, TxRoot: types.EmptyTxsHash, RequestsCount: 1, BtcHeight: btcHeight}
+		if !reflect.DeepEqual(extra, want) {
+			t.Errorf("block %d: header extra mismatch: have %+v, want %+v", block.NumberU64(), extra, want)
+		}
+	}
+
+	// The header extra committing a wrong btc height is rejected
+	header := blocks[1].Header()
+	extra, _ := goattypes.DecodeHeaderExtra(v1, header.Extra)
+	extra.BtcHeight++
+	header.Extra, _ = extra.Encode(v1)
+	invalid := blocks[1].WithSeal(header)
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(types.Blocks{blocks[0], invalid}); err == nil || !strings.Contains(err.Error(), "btc block height mismatch") {
+		t.Fatalf("expected error for the wrong btc height, got %v", err)
+	}
+	if _, err := chain.InsertChain(blocks[1:]); err != nil {
+		t.Fatalf("failed to insert the valid block: %v", err)
+	}
+}
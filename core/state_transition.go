@@ -154,6 +154,7 @@ type Message struct {
 	IsGoatTx bool            // goat tx has no gas consumed
 	Deposit  *goattypes.Mint // deposit from L1
 	Claim    *goattypes.Mint // gas fee and undelegation from consensus layer
+	GoatTx   goattypes.Tx    // the decoded goat tx, nil if it's not a goat tx
 }
 
 // TransactionToMessage converts a transaction into a Message.
@@ -178,6 +179,9 @@ func TransactionToMessage(tx *types.Transaction, s types.Signer, baseFee *big.In
 		Deposit:  tx.Deposit(),
 		Claim:    tx.Claim(),
 	}
+	if goatTx := tx.AsGoatTx(); goatTx != nil {
+		msg.GoatTx = goatTx.Inner()
+	}
 	// If baseFee provided, set gasPrice to effectiveGasPrice.
 	if baseFee != nil {
 		msg.GasPrice = msg.GasPrice.Add(msg.GasTipCap, baseFee)
@@ -252,8 +256,9 @@ func (st *StateTransition) to() common.Address {
 
 func (st *StateTransition) buyGas() error {
 	if st.msg.IsGoatTx {
-		st.initialGas = params.GoatTxGasLimit
-		st.gasRemaining = params.GoatTxGasLimit
+		goat := st.evm.ChainConfig().Goat.Params(st.evm.Context.Time)
+		st.initialGas = goat.TxGasLimit
+		st.gasRemaining = goat.TxGasLimit
 
 		if st.evm.Config.Tracer != nil && st.evm.Config.Tracer.OnGasChange != nil {
 			st.evm.Config.Tracer.OnGasChange(0, st.gasRemaining, tracing.GasChangeTxInitialBalance)
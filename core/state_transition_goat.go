@@ -34,19 +34,19 @@ func (st *StateTransition) goat(msg *Message, ret []byte, vmerr error) (*Executi
 			return nil, fmt.Errorf("goat tx error (deposit should return uint256 but got %x)", ret)
 		}
 
-		// sub the tax and pay it to GF
+		// sub the tax
 		tax := new(uint256.Int).SetBytes(ret)
-		if !tax.IsZero() {
-			if amount.Cmp(tax) < 0 {
-				return nil, fmt.Errorf("goat tx error (tax is larger: deposit %s tax %s)", v.Amount, tax)
-			}
-			amount.Sub(amount, tax)
-			st.state.AddBalance(goattypes.GoatFoundationContract, tax, tracing.BalanceGoatDepoist)
+		if amount.Cmp(tax) < 0 {
+			return nil, fmt.Errorf("goat tx error (tax is larger: deposit %s tax %s)", v.Amount, tax)
 		}
+		amount.Sub(amount, tax)
 
-		// add the deposit value(withtout tax) to the target
+		// add the deposit value(withtout tax) to the target and pay the tax to GF
 		log.Debug("NewDeposit", "address", v.Address, "amount", amount, "tax", tax)
 		st.state.AddBalance(v.Address, amount, tracing.BalanceGoatDepoist)
+		if !tax.IsZero() {
+			st.state.AddBalance(goattypes.GoatFoundationContract, tax, tracing.BalanceGoatDepositTax)
+		}
 	}
 
 	// distribute reward or unlocking amount
@@ -62,8 +62,9 @@ func (st *StateTransition) goat(msg *Message, ret []byte, vmerr error) (*Executi
 
 		// add the value to the target
 		log.Debug("NewClaim/Unlock", "address", v.Address, "amount", amount)
-		st.state.SubBalance(goattypes.LockingContract, amount, tracing.BalanceChangeTransfer)
-		st.state.AddBalance(v.Address, amount, tracing.BalanceChangeTransfer)
+		reason := goatClaimReason(msg)
+		st.state.SubBalance(goattypes.LockingContract, amount, reason)
+		st.state.AddBalance(v.Address, amount, reason)
 	}
 
 	gasUsed := st.gasUsed()
@@ -81,3 +82,12 @@ func (st *StateTransition) goat(msg *Message, ret []byte, vmerr error) (*Executi
 		ReturnData:  ret,
 	}, nil
 }
+
+// goatClaimReason returns the balance change reason of the amount claimed
+// from the locking contract
+func goatClaimReason(msg *Message) tracing.BalanceChangeReason {
+	if _, ok := msg.GoatTx.(*goattypes.CompleteUnlockTx); ok {
+		return tracing.BalanceGoatUnlock
+	}
+	return tracing.BalanceGoatReward
+}
@@ -40,6 +40,7 @@ func TestGoatStateTransition(t *testing.T) {
 		claimedValue  = big.NewInt(1e9)
 		unlockValue   = big.NewInt(1e9)
 	)
+	setupGoatTestGenesis(gspec)
 
 	coinbase := common.HexToAddress("0x41f12999e79d04ecac9a133e18588384cfb0da69")
 	depositAddress := common.HexToAddress("0x0d1b10d13d3c393206ff5c5136c7f86e3ad390ad")
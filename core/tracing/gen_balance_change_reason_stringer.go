@@ -24,23 +24,30 @@ func _() {
 	_ = x[BalanceDecreaseSelfdestruct-13]
 	_ = x[BalanceDecreaseSelfdestructBurn-14]
 	_ = x[BalanceGoatDepoist-200]
+	_ = x[BalanceGoatDepositTax-201]
+	_ = x[BalanceGoatGasTax-202]
+	_ = x[BalanceGoatGasRevenue-203]
+	_ = x[BalanceGoatReward-204]
+	_ = x[BalanceGoatUnlock-205]
 }
 
 const (
 	_BalanceChangeReason_name_0 = "BalanceChangeUnspecifiedBalanceIncreaseRewardMineUncleBalanceIncreaseRewardMineBlockBalanceIncreaseWithdrawalBalanceIncreaseGenesisBalanceBalanceIncreaseRewardTransactionFeeBalanceDecreaseGasBuyBalanceIncreaseGasReturnBalanceIncreaseDaoContractBalanceDecreaseDaoAccountBalanceChangeTransferBalanceChangeTouchAccountBalanceIncreaseSelfdestructBalanceDecreaseSelfdestructBalanceDecreaseSelfdestructBurn"
-	_BalanceChangeReason_name_1 = "BalanceGoatDepoist"
+	_BalanceChangeReason_name_1 = "BalanceGoatDepoistBalanceGoatDepositTaxBalanceGoatGasTaxBalanceGoatGasRevenueBalanceGoatRewardBalanceGoatUnlock"
 )
 
 var (
 	_BalanceChangeReason_index_0 = [...]uint16{0, 24, 54, 84, 109, 138, 173, 194, 218, 244, 269, 290, 315, 342, 369, 400}
+	_BalanceChangeReason_index_1 = [...]uint8{0, 18, 39, 56, 77, 94, 111}
 )
 
 func (i BalanceChangeReason) String() string {
 	switch {
 	case i <= 14:
 		return _BalanceChangeReason_name_0[_BalanceChangeReason_index_0[i]:_BalanceChangeReason_index_0[i+1]]
-	case i == 200:
-		return _BalanceChangeReason_name_1
+	case 200 <= i && i <= 205:
+		i -= 200
+		return _BalanceChangeReason_name_1[_BalanceChangeReason_index_1[i]:_BalanceChangeReason_index_1[i+1]]
 	default:
 		return "BalanceChangeReason(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -247,7 +247,20 @@ const (
 	BalanceDecreaseSelfdestructBurn BalanceChangeReason = 14
 
 	// goat
+	// BalanceGoatDepoist is the bridge deposit minted to the target, the tax excluded.
 	BalanceGoatDepoist BalanceChangeReason = 200
+	// BalanceGoatDepositTax is the bridge deposit tax minted to the foundation.
+	BalanceGoatDepositTax BalanceChangeReason = 201
+	// BalanceGoatGasTax is the foundation tax from the gas fees of a block.
+	BalanceGoatGasTax BalanceChangeReason = 202
+	// BalanceGoatGasRevenue is the gas fees of a block credited to the locking contract.
+	BalanceGoatGasRevenue BalanceChangeReason = 203
+	// BalanceGoatReward is the gas reward paid out of the locking contract.
+	// it is a decrease for the locking contract and an increase for the recipient.
+	BalanceGoatReward BalanceChangeReason = 204
+	// BalanceGoatUnlock is the unlocked amount paid out of the locking contract.
+	// it is a decrease for the locking contract and an increase for the recipient.
+	BalanceGoatUnlock BalanceChangeReason = 205
 )
 
 // GasChangeReason is used to indicate the reason for a gas change, useful
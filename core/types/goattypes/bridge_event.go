@@ -0,0 +1,46 @@
+package goattypes
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BridgeEventKind is the kind of a state change of a bridge withdrawal or deposit
+type BridgeEventKind uint8
+
+const (
+	BridgeWithdrawEvent BridgeEventKind = iota + 1
+	BridgeReplaceByFeeEvent
+	BridgeCancel1Event
+	BridgeCancel2Event
+	BridgePaidEvent
+	BridgeDepositEvent
+)
+
+func (k BridgeEventKind) String() string {
+	switch k {
+	case BridgeWithdrawEvent:
+		return "withdraw"
+	case BridgeReplaceByFeeEvent:
+		return "replaceByFee"
+	case BridgeCancel1Event:
+		return "cancel1"
+	case BridgeCancel2Event:
+		return "cancel2"
+	case BridgePaidEvent:
+		return "paid"
+	case BridgeDepositEvent:
+		return "deposit"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(k))
+	}
+}
+
+// BridgeEvent is a state change of a bridge withdrawal or deposit
+type BridgeEvent struct {
+	Kind        BridgeEventKind
+	BlockNumber uint64
+	BlockHash   common.Hash
+	TxHash      common.Hash
+}
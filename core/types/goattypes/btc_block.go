@@ -0,0 +1,59 @@
+package goattypes
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	// NewBlockHashEventTopic is the topic of the NewBlockHash(uint256 height) event
+	// emitted by the bitcoin contract when a NewBtcBlockTx is applied.
+	NewBlockHashEventTopic = common.HexToHash("0xdd5483f1119d050d70b0fe3ed9db0b5f41b3ec55838346cbb624efe0565b0133")
+)
+
+// UnpackToNewBlockHashEvent returns the bitcoin block height of the NewBlockHash event
+func UnpackToNewBlockHashEvent(topics []common.Hash, data []byte) (uint64, error) {
+	if len(topics) != 1 {
+		return 0, fmt.Errorf("invalid NewBlockHash event topics length: expect 1 got %d", len(topics))
+	}
+	if len(data) != 32 {
+		return 0, fmt.Errorf("invalid NewBlockHash event data length: expect 32 got %d", len(data))
+	}
+	height := new(big.Int).SetBytes(data)
+	if !height.IsUint64() {
+		return 0, fmt.Errorf("invalid bitcoin block height %s", height)
+	}
+	return height.Uint64(), nil
+}
+
+// BtcBlockRecord is a bitcoin block hash fed into the bitcoin contract and the
+// goat tx which did it.
+type BtcBlockRecord struct {
+	Hash        common.Hash // bitcoin block hash
+	BlockNumber uint64
+	BlockHash   common.Hash
+	TxHash      common.Hash
+}
+
+// BtcBlock is a bitcoin block hash recorded by the goat chain
+type BtcBlock struct {
+	Height      hexutil.Uint64 `json:"height"`
+	Hash        common.Hash    `json:"hash"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	TxHash      common.Hash    `json:"transactionHash"`
+}
+
+// NewBtcBlock returns the bitcoin block at the given height with the record
+func NewBtcBlock(height uint64, record *BtcBlockRecord) *BtcBlock {
+	return &BtcBlock{
+		Height:      hexutil.Uint64(height),
+		Hash:        record.Hash,
+		BlockNumber: hexutil.Uint64(record.BlockNumber),
+		BlockHash:   record.BlockHash,
+		TxHash:      record.TxHash,
+	}
+}
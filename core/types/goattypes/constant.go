@@ -1,6 +1,8 @@
 package goattypes
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -17,3 +19,30 @@ var (
 	BitcoinContract        = common.HexToAddress("0xbc10000000000000000000000000000000000005")
 	RelayerContract        = common.HexToAddress("0xBC10000000000000000000000000000000000006")
 )
+
+// BitcoinLatestHeightSlot is the storage slot of the bitcoin contract which stores
+// the latest bitcoin block height
+var BitcoinLatestHeightSlot = common.BigToHash(common.Big2)
+
+// FoundationFeeSharesSlot is the storage slot of the foundation contract which stores
+// the gas fee shares. The deployed foundation contract only uses the slot 0 for its
+// owner, the fee split fork requires upgrading it to the layout below:
+//
+//	address owner;               // slot 0
+//	uint256[255] __gap;          // slot 1 to 255
+//	FeeShare[] feeShares;        // slot 0x100, the length of the shares
+//	struct FeeShare {            // keccak256(0x100) + i, packed into a single slot
+//	    address recipient;       // the low 20 bytes
+//	    uint96 weight;           // the high 12 bytes, in basis points
+//	}
+var FoundationFeeSharesSlot = common.BigToHash(big.NewInt(0x100))
+
+// SystemContracts are the goat system contracts which should be deployed in the genesis
+var SystemContracts = []common.Address{
+	GoatTokenContract,
+	GoatFoundationContract,
+	BridgeContract,
+	LockingContract,
+	BitcoinContract,
+	RelayerContract,
+}
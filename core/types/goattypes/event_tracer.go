@@ -1,6 +1,7 @@
 package goattypes
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/big"
 
@@ -10,6 +11,8 @@ import (
 type DepositEvent struct {
 	Target common.Address
 	Amount *big.Int
+	Txid   common.Hash
+	TxOut  uint32
 	Tax    *big.Int
 }
 
@@ -29,6 +32,8 @@ func UnpackToDepositEvent(topics []common.Hash, data []byte) (*DepositEvent, err
 	return &DepositEvent{
 		Target: common.BytesToAddress(topics[1][:]),
 		Amount: new(big.Int).SetBytes(topics[2][:]),
+		Txid:   common.BytesToHash(data[:32]),
+		TxOut:  binary.BigEndian.Uint32(data[60:64]),
 		Tax:    new(big.Int).SetBytes(data[64:]),
 	}, nil
 }
@@ -33,6 +33,8 @@ func TestUnpackToDepositEvent(t *testing.T) {
 			want: &DepositEvent{
 				Target: common.HexToAddress("0x5B38Da6a701c568545dCfcB03FcB875f56beddC4"),
 				Amount: big.NewInt(100000000),
+				Txid:   common.HexToHash("0x26700e13983fefbd9cf16da2ed70fa5c6798ac55062a4803121a869731e308d2"),
+				TxOut:  10,
 				Tax:    big.NewInt(10000),
 			},
 		},
@@ -49,6 +51,8 @@ func TestUnpackToDepositEvent(t *testing.T) {
 			want: &DepositEvent{
 				Target: common.HexToAddress("0x8945A1288dc78A6D8952a92C77aEe6730B414778"),
 				Amount: big.NewInt(100000000),
+				Txid:   common.HexToHash("0x8ff97419363ffd7000167f130ef7168fbea05faf9251824ca5043f113cc6a7c7"),
+				TxOut:  10,
 				Tax:    new(big.Int).SetBytes(make([]byte, 32)),
 			},
 		},
@@ -0,0 +1,121 @@
+package goattypes
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// FeeRecord is the gas fees of a block and how they're distributed. The base
+// fees are not burnt in goat, they're distributed with the tips to the foundation
+// and the locking contract.
+type FeeRecord struct {
+	Burnt   *big.Int // base fees and blob fees which are burnt in ethereum
+	Tips    *big.Int // priority fees of the non-goat txs
+	Tax     *big.Int // foundation tax, or the sum of the payouts after the fee split fork
+	Revenue *big.Int // gas revenue of the locking contract
+
+	// Payouts are the gas fees paid to the recipients of the fee shares, they're
+	// only recorded after the fee split fork.
+	Payouts []*FeePayout `rlp:"optional"`
+
+	// InvalidFeeShares is set if the fee shares in the foundation contract are
+	// invalid, and the foundation tax is paid instead of them.
+	InvalidFeeShares bool `rlp:"optional"`
+}
+
+// FeePayout is the gas fees paid to a fee recipient
+type FeePayout struct {
+	Recipient common.Address
+	Amount    *big.Int
+}
+
+// FeeShare is a recipient of the gas fees with its weight in basis points
+type FeeShare struct {
+	Recipient common.Address
+	Weight    uint64
+}
+
+// StateReader reads the storage of the goat system contracts
+type StateReader interface {
+	GetState(common.Address, common.Hash) common.Hash
+}
+
+// ReadFeeShares reads the gas fee shares from the foundation contract storage. It
+// returns nil if the shares are not set, and an error if they're invalid: there are
+// too many recipients, the weights exceed the max basis points, or a recipient is
+// the zero address or the locking contract which receives the remaining fees.
+func ReadFeeShares(state StateReader) ([]FeeShare, error) {
+	length := state.GetState(GoatFoundationContract, FoundationFeeSharesSlot).Big()
+	if length.BitLen() == 0 {
+		return nil, nil
+	}
+	if !length.IsUint64() || length.Uint64() > params.GoatMaxFeeShares {
+		return nil, fmt.Errorf("too many fee shares: have %s, max %d", length, params.GoatMaxFeeShares)
+	}
+
+	var (
+		base   = new(big.Int).SetBytes(crypto.Keccak256(FoundationFeeSharesSlot[:]))
+		shares = make([]FeeShare, length.Uint64())
+		total  uint64
+	)
+	for i := range shares {
+		value := state.GetState(GoatFoundationContract, common.BigToHash(new(big.Int).Add(base, big.NewInt(int64(i)))))
+		weight := new(big.Int).SetBytes(value[:common.HashLength-common.AddressLength])
+		share := FeeShare{Recipient: common.BytesToAddress(value[common.HashLength-common.AddressLength:])}
+		switch share.Recipient {
+		case common.Address{}:
+			return nil, fmt.Errorf("fee share %d: zero recipient", i)
+		case LockingContract:
+			return nil, fmt.Errorf("fee share %d: locking contract as recipient", i)
+		}
+		if !weight.IsUint64() || weight.Uint64() > params.GoatMaxBasisPoints-total {
+			return nil, fmt.Errorf("fee share %d: total weight exceeds %d", i, params.GoatMaxBasisPoints)
+		}
+		share.Weight = weight.Uint64()
+		total += share.Weight
+		shares[i] = share
+	}
+	return shares, nil
+}
+
+// BlockRewards is the gas fee distribution of a block. The tax is the foundation
+// tax before the fee split fork, and the sum of the fee payouts after that.
+type BlockRewards struct {
+	BlockHash        common.Hash       `json:"blockHash"`
+	BlockNumber      hexutil.Uint64    `json:"blockNumber"`
+	Burnt            *hexutil.Big      `json:"burntFees"`
+	Tips             *hexutil.Big      `json:"tips"`
+	Tax              *hexutil.Big      `json:"tax"`
+	Revenue          *hexutil.Big      `json:"lockingRevenue"`
+	Payouts          []*BlockFeePayout `json:"feePayouts,omitempty"`
+	InvalidFeeShares bool              `json:"invalidFeeShares,omitempty"`
+}
+
+// BlockFeePayout is the gas fees paid to a fee recipient in the block rewards
+type BlockFeePayout struct {
+	Recipient common.Address `json:"recipient"`
+	Amount    *hexutil.Big   `json:"amount"`
+}
+
+// NewBlockRewards returns the rewards of the block with the given fee record
+func NewBlockRewards(hash common.Hash, number uint64, record *FeeRecord) *BlockRewards {
+	rewards := &BlockRewards{
+		BlockHash:   hash,
+		BlockNumber: hexutil.Uint64(number),
+		Burnt:       (*hexutil.Big)(record.Burnt),
+		Tips:        (*hexutil.Big)(record.Tips),
+		Tax:         (*hexutil.Big)(record.Tax),
+		Revenue:     (*hexutil.Big)(record.Revenue),
+
+		InvalidFeeShares: record.InvalidFeeShares,
+	}
+	for _, payout := range record.Payouts {
+		rewards.Payouts = append(rewards.Payouts, &BlockFeePayout{Recipient: payout.Recipient, Amount: (*hexutil.Big)(payout.Amount)})
+	}
+	return rewards
+}
@@ -0,0 +1,110 @@
+package goattypes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// The header extra layouts of the goat forks
+//
+//	V0: [goat tx count(1)][goat tx root(32)]
+//	V1: [version(1)][goat tx count(1)][goat tx root(32)][goat requests count(4)][btc height(8)]
+//
+// The bytes after the layout are reserved for the future goat forks and must be zero.
+const (
+	HeaderExtraV0 uint8 = iota
+	HeaderExtraV1
+)
+
+var (
+	ErrInvalidHeaderExtraLength  = errors.New("invalid goat header extra length")
+	ErrInvalidHeaderExtraVersion = errors.New("invalid goat header extra version")
+	ErrHeaderExtraReserved       = errors.New("non-zero reserved bytes in goat header extra")
+)
+
+// HeaderExtra is the goat data committed by the block header extra
+type HeaderExtra struct {
+	Version uint8
+	TxCount uint8       // number of the goat txs at the head of the block
+	TxRoot  common.Hash // trie root of the goat txs
+
+	// V1 fields
+	RequestsCount uint32 // number of the goat requests generated by the block
+	BtcHeight     uint64 // latest bitcoin block height of the bitcoin contract after the block
+}
+
+// Validate checks the header extra could be committed with the goat params
+func (h *HeaderExtra) Validate(goat params.GoatParams) error {
+	if h.Version != goat.HeaderExtraVersion {
+		return fmt.Errorf("%w: have %d, want %d", ErrInvalidHeaderExtraVersion, h.Version, goat.HeaderExtraVersion)
+	}
+	if int(h.TxCount) > goat.TxLimitPerBlock {
+		return fmt.Errorf("too many goat txs: have %d, max %d", h.TxCount, goat.TxLimitPerBlock)
+	}
+	if h.Version == HeaderExtraV0 && (h.RequestsCount != 0 || h.BtcHeight != 0) {
+		return errors.New("goat header extra v0 can't commit the requests count and btc height")
+	}
+	return nil
+}
+
+// Encode returns the header extra padded to the length of the goat params
+func (h *HeaderExtra) Encode(goat params.GoatParams) ([]byte, error) {
+	if err := h.Validate(goat); err != nil {
+		return nil, err
+	}
+	extra := make([]byte, goat.HeaderExtraLength)
+	switch h.Version {
+	case HeaderExtraV0:
+		extra[0] = h.TxCount
+		copy(extra[1:params.GoatHeaderExtraLengthV0], h.TxRoot[:])
+	case HeaderExtraV1:
+		extra[0] = h.Version
+		extra[1] = h.TxCount
+		copy(extra[2:34], h.TxRoot[:])
+		binary.BigEndian.PutUint32(extra[34:38], h.RequestsCount)
+		binary.BigEndian.PutUint64(extra[38:params.GoatHeaderExtraLengthV1], h.BtcHeight)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidHeaderExtraVersion, h.Version)
+	}
+	return extra, nil
+}
+
+// DecodeHeaderExtra decodes and validates the header extra with the goat params
+func DecodeHeaderExtra(goat params.GoatParams, extra []byte) (*HeaderExtra, error) {
+	if len(extra) != goat.HeaderExtraLength {
+		return nil, fmt.Errorf("%w: have %d, want %d", ErrInvalidHeaderExtraLength, len(extra), goat.HeaderExtraLength)
+	}
+	var (
+		h      = &HeaderExtra{Version: goat.HeaderExtraVersion}
+		length int
+	)
+	switch goat.HeaderExtraVersion {
+	case HeaderExtraV0:
+		length = params.GoatHeaderExtraLengthV0
+		h.TxCount = extra[0]
+		h.TxRoot = common.BytesToHash(extra[1:length])
+	case HeaderExtraV1:
+		length = params.GoatHeaderExtraLengthV1
+		if extra[0] != HeaderExtraV1 {
+			return nil, fmt.Errorf("%w: have %d, want %d", ErrInvalidHeaderExtraVersion, extra[0], HeaderExtraV1)
+		}
+		h.TxCount = extra[1]
+		h.TxRoot = common.BytesToHash(extra[2:34])
+		h.RequestsCount = binary.BigEndian.Uint32(extra[34:38])
+		h.BtcHeight = binary.BigEndian.Uint64(extra[38:length])
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidHeaderExtraVersion, goat.HeaderExtraVersion)
+	}
+	if len(bytes.TrimLeft(extra[length:], "\x00")) != 0 {
+		return nil, ErrHeaderExtraReserved
+	}
+	if err := h.Validate(goat); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
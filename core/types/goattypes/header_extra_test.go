@@ -0,0 +1,87 @@
+package goattypes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestHeaderExtra(t *testing.T) {
+	var (
+		root = common.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+		v0   = params.GoatParamsV0
+		v1   = params.GoatParams{TxLimitPerBlock: 128, TxGasLimit: 1, HeaderExtraLength: 48, HeaderExtraVersion: HeaderExtraV1}
+	)
+	tests := []struct {
+		goat  params.GoatParams
+		extra *HeaderExtra
+		want  string
+	}{
+		{
+			goat:  v0,
+			extra: &HeaderExtra{TxCount: 2, TxRoot: root},
+			want:  "0x0256e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		},
+		{
+			goat:  v1,
+			extra: &HeaderExtra{Version: HeaderExtraV1, TxCount: 2, TxRoot: root, RequestsCount: 3, BtcHeight: 0x30b363},
+			want:  "0x010256e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b42100000003000000000030b3630000",
+		},
+	}
+	for i, tt := range tests {
+		raw, err := tt.extra.Encode(tt.goat)
+		if err != nil {
+			t.Fatalf("test %d: failed to encode: %v", i, err)
+		}
+		if have := hexutil.Encode(raw); have != tt.want {
+			t.Errorf("test %d: encoding mismatch: have %s, want %s", i, have, tt.want)
+		}
+		decoded, err := DecodeHeaderExtra(tt.goat, raw)
+		if err != nil {
+			t.Fatalf("test %d: failed to decode: %v", i, err)
+		}
+		if !reflect.DeepEqual(decoded, tt.extra) {
+			t.Errorf("test %d: decoding mismatch: have %+v, want %+v", i, decoded, tt.extra)
+		}
+	}
+}
+
+func TestDecodeInvalidHeaderExtra(t *testing.T) {
+	var (
+		v0    = params.GoatParamsV0
+		v1    = params.GoatParams{TxLimitPerBlock: 2, TxGasLimit: 1, HeaderExtraLength: 48, HeaderExtraVersion: HeaderExtraV1}
+		valid = hexutil.MustDecode("0x010256e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b42100000003000000000030b3630000")
+	)
+	modify := func(f func([]byte) []byte) []byte {
+		return f(common.CopyBytes(valid))
+	}
+	tests := []struct {
+		goat    params.GoatParams
+		extra   []byte
+		wantErr error
+	}{
+		{v1, valid[:47], ErrInvalidHeaderExtraLength},
+		{v0, valid, ErrInvalidHeaderExtraLength},
+		{v1, modify(func(b []byte) []byte { b[0] = 0; return b }), ErrInvalidHeaderExtraVersion},
+		{v1, modify(func(b []byte) []byte { b[47] = 1; return b }), ErrHeaderExtraReserved},
+		{v1, modify(func(b []byte) []byte { b[1] = 3; return b }), errors.New("too many goat txs: have 3, max 2")},
+		{params.GoatParams{HeaderExtraLength: 48, HeaderExtraVersion: 2}, valid, ErrInvalidHeaderExtraVersion},
+	}
+	for i, tt := range tests {
+		_, err := DecodeHeaderExtra(tt.goat, tt.extra)
+		if err == nil {
+			t.Errorf("test %d: expected error %v", i, tt.wantErr)
+		} else if !errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error() {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.wantErr)
+		}
+	}
+
+	// The v0 layout can't commit the v1 fields
+	if _, err := (&HeaderExtra{BtcHeight: 1}).Encode(v0); err == nil {
+		t.Error("expected error for the btc height in the v0 layout")
+	}
+}
@@ -0,0 +1,211 @@
+package goattypes
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// LockingEventKind is the kind of a locking request or a locking goat tx
+type LockingEventKind uint8
+
+const (
+	LockingCreateEvent LockingEventKind = iota + 1
+	LockingLockEvent
+	LockingUnlockEvent
+	LockingClaimEvent
+	LockingGrantEvent
+	LockingUpdateWeightEvent
+	LockingUpdateThresholdEvent
+	LockingCompleteUnlockEvent
+	LockingDistributeRewardEvent
+)
+
+func (k LockingEventKind) String() string {
+	switch k {
+	case LockingCreateEvent:
+		return "create"
+	case LockingLockEvent:
+		return "lock"
+	case LockingUnlockEvent:
+		return "unlock"
+	case LockingClaimEvent:
+		return "claim"
+	case LockingGrantEvent:
+		return "grant"
+	case LockingUpdateWeightEvent:
+		return "updateTokenWeight"
+	case LockingUpdateThresholdEvent:
+		return "updateTokenThreshold"
+	case LockingCompleteUnlockEvent:
+		return "completeUnlock"
+	case LockingDistributeRewardEvent:
+		return "distributeReward"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(k))
+	}
+}
+
+// LockingEvent is a locking request or a locking goat tx of a block
+type LockingEvent struct {
+	Kind        LockingEventKind
+	BlockNumber uint64
+	BlockHash   common.Hash
+	TxHash      common.Hash
+	Data        []byte // the encoded request or goat tx
+}
+
+// Decode returns the locking request or the goat tx of the event
+func (e *LockingEvent) Decode() (any, error) {
+	var (
+		req Request
+		tx  Tx
+	)
+	switch e.Kind {
+	case LockingCreateEvent:
+		req = new(CreateRequest)
+	case LockingLockEvent:
+		req = new(LockRequest)
+	case LockingUnlockEvent:
+		req = new(UnlockRequest)
+	case LockingClaimEvent:
+		req = new(ClaimRequest)
+	case LockingGrantEvent:
+		req = new(GrantRequest)
+	case LockingUpdateWeightEvent:
+		req = new(UpdateTokenWeightRequest)
+	case LockingUpdateThresholdEvent:
+		req = new(UpdateTokenThresholdRequest)
+	case LockingCompleteUnlockEvent:
+		tx = new(CompleteUnlockTx)
+	case LockingDistributeRewardEvent:
+		tx = new(DistributeRewardTx)
+	default:
+		return nil, fmt.Errorf("unknown locking event %s", e.Kind)
+	}
+	if req != nil {
+		return req, req.Decode(e.Data)
+	}
+	return tx, tx.Decode(e.Data)
+}
+
+// ClaimedRewards is the rewards distributed to a validator
+type ClaimedRewards struct {
+	Goat      *hexutil.Big `json:"goat"`
+	GasReward *hexutil.Big `json:"gasReward"`
+}
+
+// LockingRequestRecord is the validator of an unlock or claim request, recorded
+// with the block of the request so that the record of a reorged block is skipped.
+type LockingRequestRecord struct {
+	Validator   common.Address
+	BlockNumber uint64
+	BlockHash   common.Hash
+}
+
+// Validator is the locking state of a validator folded from its locking events
+type Validator struct {
+	Address        common.Address                  `json:"address"`
+	Pubkey         hexutil.Bytes                   `json:"pubkey"`
+	Locked         map[common.Address]*hexutil.Big `json:"locked"`
+	PendingUnlocks []*UnlockRequest                `json:"pendingUnlocks"`
+	PendingClaims  []*ClaimRequest                 `json:"pendingClaims"`
+	Claimed        ClaimedRewards                  `json:"claimedRewards"`
+}
+
+// NewValidator returns an empty validator state
+func NewValidator(address common.Address) *Validator {
+	return &Validator{
+		Address:        address,
+		Locked:         make(map[common.Address]*hexutil.Big),
+		PendingUnlocks: []*UnlockRequest{},
+		PendingClaims:  []*ClaimRequest{},
+		Claimed:        ClaimedRewards{Goat: new(hexutil.Big), GasReward: new(hexutil.Big)},
+	}
+}
+
+// Apply folds the locking event into the validator state
+func (v *Validator) Apply(event *LockingEvent) error {
+	decoded, err := event.Decode()
+	if err != nil {
+		return err
+	}
+	switch item := decoded.(type) {
+	case *CreateRequest:
+		v.Pubkey = item.Pubkey[:]
+	case *LockRequest:
+		v.addLocked(item.Token, item.Amount)
+	case *UnlockRequest:
+		v.addLocked(item.Token, new(big.Int).Neg(item.Amount))
+		v.PendingUnlocks = append(v.PendingUnlocks, item)
+	case *ClaimRequest:
+		v.PendingClaims = append(v.PendingClaims, item)
+	case *CompleteUnlockTx:
+		v.PendingUnlocks = slices.DeleteFunc(v.PendingUnlocks, func(req *UnlockRequest) bool { return req.Id == item.Id })
+	case *DistributeRewardTx:
+		v.PendingClaims = slices.DeleteFunc(v.PendingClaims, func(req *ClaimRequest) bool { return req.Id == item.Id })
+		v.Claimed.Goat.ToInt().Add(v.Claimed.Goat.ToInt(), item.Goat)
+		v.Claimed.GasReward.ToInt().Add(v.Claimed.GasReward.ToInt(), item.GasReward)
+	default:
+		return fmt.Errorf("unexpected %s event of validator %s", event.Kind, v.Address)
+	}
+	return nil
+}
+
+func (v *Validator) addLocked(token common.Address, amount *big.Int) {
+	locked, ok := v.Locked[token]
+	if !ok {
+		locked = new(hexutil.Big)
+		v.Locked[token] = locked
+	}
+	locked.ToInt().Add(locked.ToInt(), amount)
+}
+
+// LockingToken is the locking parameters of a token
+type LockingToken struct {
+	Weight    hexutil.Uint64 `json:"weight"`
+	Threshold *hexutil.Big   `json:"threshold"`
+}
+
+// LockingParams is the global locking state folded from the grant and the token
+// update events
+type LockingParams struct {
+	Tokens  map[common.Address]*LockingToken `json:"tokens"`
+	Granted *hexutil.Big                     `json:"granted"`
+}
+
+// NewLockingParams returns an empty locking params state
+func NewLockingParams() *LockingParams {
+	return &LockingParams{Tokens: make(map[common.Address]*LockingToken), Granted: new(hexutil.Big)}
+}
+
+// Apply folds the locking event into the locking params
+func (p *LockingParams) Apply(event *LockingEvent) error {
+	decoded, err := event.Decode()
+	if err != nil {
+		return err
+	}
+	switch item := decoded.(type) {
+	case *GrantRequest:
+		p.Granted.ToInt().Add(p.Granted.ToInt(), item.Amount)
+	case *UpdateTokenWeightRequest:
+		p.token(item.Token).Weight = hexutil.Uint64(item.Weight)
+	case *UpdateTokenThresholdRequest:
+		p.token(item.Token).Threshold = (*hexutil.Big)(item.Threshold)
+	default:
+		return fmt.Errorf("unexpected %s event of locking params", event.Kind)
+	}
+	return nil
+}
+
+func (p *LockingParams) token(address common.Address) *LockingToken {
+	token, ok := p.Tokens[address]
+	if !ok {
+		token = &LockingToken{Threshold: new(hexutil.Big)}
+		p.Tokens[address] = token
+	}
+	return token
+}
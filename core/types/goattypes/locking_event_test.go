@@ -0,0 +1,74 @@
+package goattypes
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestValidatorApply(t *testing.T) {
+	var (
+		validator = common.HexToAddress("0x8945a1288dc78a6d8952a92c77aee6730b414778")
+		recipient = common.HexToAddress("0x5b38da6a701c568545dcfcb03fcb875f56beddc4")
+		token     = common.Address{}
+		pubkey    = [64]byte{1, 2, 3}
+	)
+	events := []LockingEvent{
+		{Kind: LockingCreateEvent, Data: (&CreateRequest{Validator: validator, Pubkey: pubkey}).Encode()},
+		{Kind: LockingLockEvent, Data: (&LockRequest{Validator: validator, Token: token, Amount: big.NewInt(100)}).Encode()},
+		{Kind: LockingUnlockEvent, Data: (&UnlockRequest{Id: 1, Validator: validator, Recipient: recipient, Token: token, Amount: big.NewInt(30)}).Encode()},
+		{Kind: LockingUnlockEvent, Data: (&UnlockRequest{Id: 2, Validator: validator, Recipient: recipient, Token: token, Amount: big.NewInt(20)}).Encode()},
+		{Kind: LockingClaimEvent, Data: (&ClaimRequest{Id: 5, Validator: validator, Recipient: recipient}).Encode()},
+		{Kind: LockingCompleteUnlockEvent, Data: (&CompleteUnlockTx{Id: 1, Recipient: recipient, Token: token, Amount: big.NewInt(30)}).Encode()},
+		{Kind: LockingDistributeRewardEvent, Data: (&DistributeRewardTx{Id: 5, Recipient: recipient, Goat: big.NewInt(7), GasReward: big.NewInt(9)}).Encode()},
+	}
+	state := NewValidator(validator)
+	for i := range events {
+		if err := state.Apply(&events[i]); err != nil {
+			t.Fatalf("event %d: %v", i, err)
+		}
+	}
+	want := &Validator{
+		Address:        validator,
+		Pubkey:         pubkey[:],
+		Locked:         map[common.Address]*hexutil.Big{token: (*hexutil.Big)(big.NewInt(50))},
+		PendingUnlocks: []*UnlockRequest{{Id: 2, Validator: validator, Recipient: recipient, Token: token, Amount: big.NewInt(20)}},
+		PendingClaims:  []*ClaimRequest{},
+		Claimed:        ClaimedRewards{Goat: (*hexutil.Big)(big.NewInt(7)), GasReward: (*hexutil.Big)(big.NewInt(9))},
+	}
+	if !reflect.DeepEqual(state, want) {
+		t.Errorf("validator state mismatch: have %+v, want %+v", state, want)
+	}
+
+	// The grant and token events are not validator events
+	grant := &LockingEvent{Kind: LockingGrantEvent, Data: (&GrantRequest{Amount: big.NewInt(1)}).Encode()}
+	if err := state.Apply(grant); err == nil {
+		t.Error("expected error for the grant event")
+	}
+}
+
+func TestLockingParamsApply(t *testing.T) {
+	token := common.HexToAddress("0x5b38da6a701c568545dcfcb03fcb875f56beddc4")
+	events := []LockingEvent{
+		{Kind: LockingGrantEvent, Data: (&GrantRequest{Amount: big.NewInt(10)}).Encode()},
+		{Kind: LockingUpdateWeightEvent, Data: (&UpdateTokenWeightRequest{Token: token, Weight: 3}).Encode()},
+		{Kind: LockingUpdateThresholdEvent, Data: (&UpdateTokenThresholdRequest{Token: token, Threshold: big.NewInt(1000)}).Encode()},
+		{Kind: LockingGrantEvent, Data: (&GrantRequest{Amount: big.NewInt(5)}).Encode()},
+	}
+	params := NewLockingParams()
+	for i := range events {
+		if err := params.Apply(&events[i]); err != nil {
+			t.Fatalf("event %d: %v", i, err)
+		}
+	}
+	want := &LockingParams{
+		Tokens:  map[common.Address]*LockingToken{token: {Weight: 3, Threshold: (*hexutil.Big)(big.NewInt(1000))}},
+		Granted: (*hexutil.Big)(big.NewInt(15)),
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("locking params mismatch: have %+v, want %+v", params, want)
+	}
+}
@@ -11,9 +11,9 @@ import (
 )
 
 type BridgeRequests struct {
-	Withdraws     []*WithdrawalRequest
-	ReplaceByFees []*ReplaceByFeeRequest
-	Cancel1s      []*Cancel1Request
+	Withdraws     []*WithdrawalRequest   `json:"withdrawals"`
+	ReplaceByFees []*ReplaceByFeeRequest `json:"replaceByFees"`
+	Cancel1s      []*Cancel1Request      `json:"cancel1s"`
 }
 
 func (reqs *BridgeRequests) Encode() [][]byte {
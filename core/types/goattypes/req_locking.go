@@ -10,14 +10,14 @@ import (
 )
 
 type LockingRequests struct {
-	Gas              []*GasRequest
-	Creates          []*CreateRequest
-	Locks            []*LockRequest
-	Unlocks          []*UnlockRequest
-	Claims           []*ClaimRequest
-	Grants           []*GrantRequest
-	UpdateWeights    []*UpdateTokenWeightRequest
-	UpdateThresholds []*UpdateTokenThresholdRequest
+	Gas              []*GasRequest                  `json:"gas"`
+	Creates          []*CreateRequest               `json:"creates"`
+	Locks            []*LockRequest                 `json:"locks"`
+	Unlocks          []*UnlockRequest               `json:"unlocks"`
+	Claims           []*ClaimRequest                `json:"claims"`
+	Grants           []*GrantRequest                `json:"grants"`
+	UpdateWeights    []*UpdateTokenWeightRequest    `json:"updateTokenWeights"`
+	UpdateThresholds []*UpdateTokenThresholdRequest `json:"updateTokenThresholds"`
 }
 
 func (reqs *LockingRequests) Encode() [][]byte {
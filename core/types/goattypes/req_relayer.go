@@ -9,8 +9,8 @@ import (
 )
 
 type RelayerRequests struct {
-	Adds    []*AddVoterRequest
-	Removes []*RemoveVoterRequest
+	Adds    []*AddVoterRequest    `json:"addVoters"`
+	Removes []*RemoveVoterRequest `json:"removeVoters"`
 }
 
 func (reqs *RelayerRequests) Encode() [][]byte {
@@ -27,8 +27,8 @@ func (reqs *RelayerRequests) Encode() [][]byte {
 }
 
 type AddVoterRequest struct {
-	Voter  common.Address
-	Pubkey common.Hash
+	Voter  common.Address `json:"voter"`
+	Pubkey common.Hash    `json:"pubkey"`
 }
 
 func UnpackIntoAddVoterRequest(topics []common.Hash, data []byte) (*AddVoterRequest, error) {
@@ -79,7 +79,7 @@ func (req *AddVoterRequest) Copy() Request {
 }
 
 type RemoveVoterRequest struct {
-	Voter common.Address
+	Voter common.Address `json:"voter"`
 }
 
 func UnpackIntoRemoveVoterRequest(topics []common.Hash, data []byte) (*RemoveVoterRequest, error) {
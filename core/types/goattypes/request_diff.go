@@ -0,0 +1,121 @@
+package goattypes
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Requests is the decoded goat requests of a block
+type Requests struct {
+	Locking LockingRequests `json:"locking"`
+	Bridge  BridgeRequests  `json:"bridge"`
+	Relayer RelayerRequests `json:"relayer"`
+}
+
+// NewRequests decodes the typed goat requests of a block
+func NewRequests(reqs [][]byte) (*Requests, error) {
+	bridge, relayer, locking, err := DecodeRequests(reqs, true)
+	if err != nil {
+		return nil, err
+	}
+	return &Requests{Locking: locking, Bridge: bridge, Relayer: relayer}, nil
+}
+
+// Encode returns the typed requests sorted by the request type
+func (reqs *Requests) Encode() [][]byte {
+	var res [][]byte
+	res = append(res, reqs.Locking.Encode()...)
+	res = append(res, reqs.Bridge.Encode()...)
+	res = append(res, reqs.Relayer.Encode()...)
+	return res
+}
+
+// Validate checks if there is a missing request in the lists
+func (reqs *Requests) Validate() error {
+	for _, list := range reqs.byType() {
+		for _, req := range list {
+			if req == nil {
+				return errors.New("nil goat request")
+			}
+		}
+	}
+	return nil
+}
+
+// Len returns the number of the requests
+func (reqs *Requests) Len() int {
+	var n int
+	for _, list := range reqs.byType() {
+		n += len(list)
+	}
+	return n
+}
+
+// byType returns the requests indexed by the request type
+func (reqs *Requests) byType() [][]Request {
+	return [][]Request{
+		GasRequestType:                  toRequests(reqs.Locking.Gas),
+		CreateRequestType:               toRequests(reqs.Locking.Creates),
+		LockRequestType:                 toRequests(reqs.Locking.Locks),
+		UnlockRequestType:               toRequests(reqs.Locking.Unlocks),
+		ClaimRequestType:                toRequests(reqs.Locking.Claims),
+		GrantRequestType:                toRequests(reqs.Locking.Grants),
+		UpdateTokenWeightRequestType:    toRequests(reqs.Locking.UpdateWeights),
+		UpdateTokenThresholdRequestType: toRequests(reqs.Locking.UpdateThresholds),
+		WithdrawalRequestType:           toRequests(reqs.Bridge.Withdraws),
+		ReplaceByFeeRequestType:         toRequests(reqs.Bridge.ReplaceByFees),
+		Cancel1RequestType:              toRequests(reqs.Bridge.Cancel1s),
+		AddVoterRequestType:             toRequests(reqs.Relayer.Adds),
+		RemoveVoterRequestType:          toRequests(reqs.Relayer.Removes),
+	}
+}
+
+// toRequests converts the typed list, the nil pointers are kept as nil requests
+func toRequests[T Request](list []T) []Request {
+	var (
+		res  = make([]Request, len(list))
+		null T
+	)
+	for i, req := range list {
+		if any(req) != any(null) {
+			res[i] = req
+		}
+	}
+	return res
+}
+
+// RequestDiff is a goat request diverged from the expected one, the missing
+// or unexpected request is nil.
+type RequestDiff struct {
+	Type     hexutil.Uint64 `json:"type"`
+	Index    hexutil.Uint64 `json:"index"`
+	Expected Request        `json:"expected"`
+	Actual   Request        `json:"actual"`
+}
+
+// DiffRequests compares the requests of the same type one by one, and returns
+// the diverged ones sorted by the request type and index.
+func DiffRequests(expected, actual *Requests) []*RequestDiff {
+	var (
+		diffs []*RequestDiff
+		have  = actual.byType()
+	)
+	for typ, want := range expected.byType() {
+		for i := 0; i < max(len(want), len(have[typ])); i++ {
+			diff := &RequestDiff{Type: hexutil.Uint64(typ), Index: hexutil.Uint64(i)}
+			if i < len(want) {
+				diff.Expected = want[i]
+			}
+			if i < len(have[typ]) {
+				diff.Actual = have[typ][i]
+			}
+			if diff.Expected != nil && diff.Actual != nil && bytes.Equal(diff.Expected.Encode(), diff.Actual.Encode()) {
+				continue
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
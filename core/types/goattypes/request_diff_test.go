@@ -0,0 +1,52 @@
+package goattypes
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDiffRequests(t *testing.T) {
+	var (
+		validator = common.HexToAddress("0xbc10000000000000000000000000000000000001")
+		token     = common.HexToAddress("0xbc10000000000000000000000000000000000002")
+		withdraw  = &WithdrawalRequest{Id: 3, Amount: 1e8, TxPrice: 10, Address: "bc1qen5kv3c0epd9yfqvu2q059qsjpwu9hdjywx2v9p5p9l8msxn88fs9y5kx6"}
+		lock      = &LockRequest{Validator: validator, Token: token, Amount: big.NewInt(100)}
+	)
+	actual := &Requests{
+		Locking: LockingRequests{
+			Gas:   []*GasRequest{{Height: 10, Amount: big.NewInt(1e18)}},
+			Locks: []*LockRequest{lock},
+		},
+		Bridge: BridgeRequests{Withdraws: []*WithdrawalRequest{withdraw}},
+	}
+	decoded, err := NewRequests(actual.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diffs := DiffRequests(actual, decoded); len(diffs) != 0 {
+		t.Fatalf("unexpected diffs of the same requests: %v", diffs)
+	}
+
+	// The gas revenue diverges, the lock is missing and the withdrawal is unexpected
+	expected := &Requests{
+		Locking: LockingRequests{
+			Gas: []*GasRequest{{Height: 10, Amount: big.NewInt(2e18)}},
+		},
+		Bridge: BridgeRequests{Withdraws: []*WithdrawalRequest{withdraw, withdraw}},
+	}
+	want := []*RequestDiff{
+		{Type: 0, Index: 0, Expected: expected.Locking.Gas[0], Actual: actual.Locking.Gas[0]},
+		{Type: 2, Index: 0, Expected: nil, Actual: lock},
+		{Type: 8, Index: 1, Expected: withdraw, Actual: nil},
+	}
+	if have := DiffRequests(expected, actual); !reflect.DeepEqual(have, want) {
+		t.Errorf("unexpected diffs: have %v, want %v", have, want)
+	}
+
+	if err := (&Requests{Bridge: BridgeRequests{Cancel1s: []*Cancel1Request{nil}}}).Validate(); err == nil {
+		t.Error("expected error for the nil request")
+	}
+}
@@ -0,0 +1,248 @@
+package goattypes
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var errInvalidPubkeyLength = errors.New("invalid pubkey length")
+
+// BlockRequests is the decoded goat requests of a block
+type BlockRequests struct {
+	BlockHash    common.Hash     `json:"blockHash"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	RequestsHash common.Hash     `json:"requestsHash"`
+	Locking      LockingRequests `json:"locking"`
+	Bridge       BridgeRequests  `json:"bridge"`
+	Relayer      RelayerRequests `json:"relayer"`
+}
+
+type withdrawalRequestJSON struct {
+	Id      hexutil.Uint64 `json:"id"`
+	Amount  hexutil.Uint64 `json:"amount"`
+	TxPrice hexutil.Uint64 `json:"txPrice"`
+	Address string         `json:"address"`
+}
+
+func (req WithdrawalRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&withdrawalRequestJSON{
+		Id:      hexutil.Uint64(req.Id),
+		Amount:  hexutil.Uint64(req.Amount),
+		TxPrice: hexutil.Uint64(req.TxPrice),
+		Address: req.Address,
+	})
+}
+
+func (req *WithdrawalRequest) UnmarshalJSON(input []byte) error {
+	var dec withdrawalRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Id, req.Amount, req.TxPrice, req.Address = uint64(dec.Id), uint64(dec.Amount), uint64(dec.TxPrice), dec.Address
+	return nil
+}
+
+type replaceByFeeRequestJSON struct {
+	Id      hexutil.Uint64 `json:"id"`
+	TxPrice hexutil.Uint64 `json:"txPrice"`
+}
+
+func (req ReplaceByFeeRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&replaceByFeeRequestJSON{Id: hexutil.Uint64(req.Id), TxPrice: hexutil.Uint64(req.TxPrice)})
+}
+
+func (req *ReplaceByFeeRequest) UnmarshalJSON(input []byte) error {
+	var dec replaceByFeeRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Id, req.TxPrice = uint64(dec.Id), uint64(dec.TxPrice)
+	return nil
+}
+
+type cancel1RequestJSON struct {
+	Id hexutil.Uint64 `json:"id"`
+}
+
+func (req Cancel1Request) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&cancel1RequestJSON{Id: hexutil.Uint64(req.Id)})
+}
+
+func (req *Cancel1Request) UnmarshalJSON(input []byte) error {
+	var dec cancel1RequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Id = uint64(dec.Id)
+	return nil
+}
+
+type gasRequestJSON struct {
+	Height hexutil.Uint64 `json:"height"`
+	Amount *hexutil.Big   `json:"amount"`
+}
+
+func (req GasRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&gasRequestJSON{Height: hexutil.Uint64(req.Height), Amount: (*hexutil.Big)(req.Amount)})
+}
+
+func (req *GasRequest) UnmarshalJSON(input []byte) error {
+	var dec gasRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Height, req.Amount = uint64(dec.Height), bigOrZero(dec.Amount)
+	return nil
+}
+
+type createRequestJSON struct {
+	Validator common.Address `json:"validator"`
+	Pubkey    hexutil.Bytes  `json:"pubkey"`
+}
+
+func (req CreateRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&createRequestJSON{Validator: req.Validator, Pubkey: req.Pubkey[:]})
+}
+
+func (req *CreateRequest) UnmarshalJSON(input []byte) error {
+	var dec createRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if len(dec.Pubkey) != len(req.Pubkey) {
+		return errInvalidPubkeyLength
+	}
+	req.Validator = dec.Validator
+	copy(req.Pubkey[:], dec.Pubkey)
+	return nil
+}
+
+type lockRequestJSON struct {
+	Validator common.Address `json:"validator"`
+	Token     common.Address `json:"token"`
+	Amount    *hexutil.Big   `json:"amount"`
+}
+
+func (req LockRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&lockRequestJSON{Validator: req.Validator, Token: req.Token, Amount: (*hexutil.Big)(req.Amount)})
+}
+
+func (req *LockRequest) UnmarshalJSON(input []byte) error {
+	var dec lockRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Validator, req.Token, req.Amount = dec.Validator, dec.Token, bigOrZero(dec.Amount)
+	return nil
+}
+
+type unlockRequestJSON struct {
+	Id        hexutil.Uint64 `json:"id"`
+	Validator common.Address `json:"validator"`
+	Recipient common.Address `json:"recipient"`
+	Token     common.Address `json:"token"`
+	Amount    *hexutil.Big   `json:"amount"`
+}
+
+func (req UnlockRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&unlockRequestJSON{
+		Id:        hexutil.Uint64(req.Id),
+		Validator: req.Validator,
+		Recipient: req.Recipient,
+		Token:     req.Token,
+		Amount:    (*hexutil.Big)(req.Amount),
+	})
+}
+
+func (req *UnlockRequest) UnmarshalJSON(input []byte) error {
+	var dec unlockRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Id, req.Validator, req.Recipient = uint64(dec.Id), dec.Validator, dec.Recipient
+	req.Token, req.Amount = dec.Token, bigOrZero(dec.Amount)
+	return nil
+}
+
+type claimRequestJSON struct {
+	Id        hexutil.Uint64 `json:"id"`
+	Validator common.Address `json:"validator"`
+	Recipient common.Address `json:"recipient"`
+}
+
+func (req ClaimRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&claimRequestJSON{Id: hexutil.Uint64(req.Id), Validator: req.Validator, Recipient: req.Recipient})
+}
+
+func (req *ClaimRequest) UnmarshalJSON(input []byte) error {
+	var dec claimRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Id, req.Validator, req.Recipient = uint64(dec.Id), dec.Validator, dec.Recipient
+	return nil
+}
+
+type updateTokenWeightRequestJSON struct {
+	Token  common.Address `json:"token"`
+	Weight hexutil.Uint64 `json:"weight"`
+}
+
+func (req UpdateTokenWeightRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&updateTokenWeightRequestJSON{Token: req.Token, Weight: hexutil.Uint64(req.Weight)})
+}
+
+func (req *UpdateTokenWeightRequest) UnmarshalJSON(input []byte) error {
+	var dec updateTokenWeightRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Token, req.Weight = dec.Token, uint64(dec.Weight)
+	return nil
+}
+
+type updateTokenThresholdRequestJSON struct {
+	Token     common.Address `json:"token"`
+	Threshold *hexutil.Big   `json:"threshold"`
+}
+
+func (req UpdateTokenThresholdRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&updateTokenThresholdRequestJSON{Token: req.Token, Threshold: (*hexutil.Big)(req.Threshold)})
+}
+
+func (req *UpdateTokenThresholdRequest) UnmarshalJSON(input []byte) error {
+	var dec updateTokenThresholdRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Token, req.Threshold = dec.Token, bigOrZero(dec.Threshold)
+	return nil
+}
+
+type grantRequestJSON struct {
+	Amount *hexutil.Big `json:"amount"`
+}
+
+func (req GrantRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&grantRequestJSON{Amount: (*hexutil.Big)(req.Amount)})
+}
+
+func (req *GrantRequest) UnmarshalJSON(input []byte) error {
+	var dec grantRequestJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	req.Amount = bigOrZero(dec.Amount)
+	return nil
+}
+
+func bigOrZero(b *hexutil.Big) *big.Int {
+	if b == nil {
+		return new(big.Int)
+	}
+	return b.ToInt()
+}
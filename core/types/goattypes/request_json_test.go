@@ -0,0 +1,62 @@
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestRequestsJSON(t *testing.T) {
+	var pubkey [64]byte
+	for i := range pubkey {
+		pubkey[i] = byte(i)
+	}
+	var (
+		validator = common.HexToAddress("0xbc10000000000000000000000000000000000001")
+		token     = common.HexToAddress("0xbc10000000000000000000000000000000000002")
+	)
+	want := BlockRequests{
+		BlockHash:    common.HexToHash("0x01"),
+		BlockNumber:  10,
+		RequestsHash: common.HexToHash("0x02"),
+		Locking: LockingRequests{
+			Gas:              []*GasRequest{{Height: 10, Amount: big.NewInt(1e18)}},
+			Creates:          []*CreateRequest{{Validator: validator, Pubkey: pubkey}},
+			Locks:            []*LockRequest{{Validator: validator, Token: token, Amount: big.NewInt(100)}},
+			Unlocks:          []*UnlockRequest{{Id: 1, Validator: validator, Recipient: validator, Token: token, Amount: big.NewInt(200)}},
+			Claims:           []*ClaimRequest{{Id: 2, Validator: validator, Recipient: validator}},
+			Grants:           []*GrantRequest{{Amount: big.NewInt(300)}},
+			UpdateWeights:    []*UpdateTokenWeightRequest{{Token: token, Weight: 3}},
+			UpdateThresholds: []*UpdateTokenThresholdRequest{{Token: token, Threshold: big.NewInt(400)}},
+		},
+		Bridge: BridgeRequests{
+			Withdraws:     []*WithdrawalRequest{{Id: 3, Amount: 1e8, TxPrice: 10, Address: "bc1qen5kv3c0epd9yfqvu2q059qsjpwu9hdjywx2v9p5p9l8msxn88fs9y5kx6"}},
+			ReplaceByFees: []*ReplaceByFeeRequest{{Id: 3, TxPrice: 20}},
+			Cancel1s:      []*Cancel1Request{{Id: 4}},
+		},
+		Relayer: RelayerRequests{
+			Adds:    []*AddVoterRequest{{Voter: validator, Pubkey: common.HexToHash("0x03")}},
+			Removes: []*RemoveVoterRequest{{Voter: validator}},
+		},
+	}
+
+	enc, err := json.Marshal(&want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got BlockRequests
+	if err := json.Unmarshal(enc, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("json round trip mismatch: have %+v, want %+v", got, want)
+	}
+
+	var create CreateRequest
+	if err := json.Unmarshal([]byte(`{"validator":"0xbc10000000000000000000000000000000000001","pubkey":"0x01"}`), &create); err == nil {
+		t.Error("expected error for the invalid pubkey length")
+	}
+}
@@ -12,6 +12,7 @@ type Module uint8
 const (
 	BirdgeModule Module = iota + 1
 	LockingModule
+	RelayerModule
 )
 
 type Action uint8
@@ -35,31 +36,65 @@ type Tx interface {
 	MethodId() [4]byte
 }
 
-func DecodeTx(module Module, action Action, data []byte) (Tx, error) {
-	var inner Tx
-	switch module {
-	case BirdgeModule:
-		switch action {
-		case BridgeDepoitAction:
-			inner = new(DepositTx)
-		case BridgeCancel2Action:
-			inner = new(Cancel2Tx)
-		case BridgePaidAction:
-			inner = new(PaidTx)
-		case BitcoinNewBlockAction:
-			inner = new(NewBtcBlockTx)
-		}
-	case LockingModule:
-		switch action {
-		case LockingCompleteUnlockAction:
-			inner = new(CompleteUnlockTx)
-		case LockingDistributeRewardAction:
-			inner = new(DistributeRewardTx)
-		}
+// goatModule is a goat module registered with its executor and actions
+type goatModule struct {
+	name     string
+	executor common.Address // the sender of the goat txs of the module
+	actions  map[Action]goatAction
+}
+
+// goatAction is a goat tx type registered with its name shown by the RPC
+type goatAction struct {
+	name  string
+	newTx func() Tx
+}
+
+// txRegistry is the goat modules and their actions, a new goat tx type should
+// be registered here.
+var txRegistry = map[Module]goatModule{
+	BirdgeModule: {name: "bridge", executor: RelayerExecutor, actions: map[Action]goatAction{
+		BridgeDepoitAction:    {"deposit", func() Tx { return new(DepositTx) }},
+		BridgeCancel2Action:   {"cancel2", func() Tx { return new(Cancel2Tx) }},
+		BridgePaidAction:      {"paid", func() Tx { return new(PaidTx) }},
+		BitcoinNewBlockAction: {"newBtcBlock", func() Tx { return new(NewBtcBlockTx) }},
+	}},
+	LockingModule: {name: "locking", executor: LockingExecutor, actions: map[Action]goatAction{
+		LockingCompleteUnlockAction:   {"completeUnlock", func() Tx { return new(CompleteUnlockTx) }},
+		LockingDistributeRewardAction: {"distributeReward", func() Tx { return new(DistributeRewardTx) }},
+	}},
+	RelayerModule: {name: "relayer", executor: RelayerExecutor, actions: map[Action]goatAction{
+		RelayerAddVoterAction:    {"addVoter", func() Tx { return new(AddVoterTx) }},
+		RelayerRemoveVoterAction: {"removeVoter", func() Tx { return new(RemoveVoterTx) }},
+	}},
+}
+
+func (m Module) String() string {
+	if module, ok := txRegistry[m]; ok {
+		return module.name
 	}
-	if inner == nil {
+	return fmt.Sprintf("unknown(%d)", uint8(m))
+}
+
+// Executor returns the executor account which sends the goat txs of the module,
+// the zero address is returned for an unknown module.
+func (m Module) Executor() common.Address {
+	return txRegistry[m].executor
+}
+
+// ActionName returns the name of the action of the goat module
+func ActionName(module Module, action Action) string {
+	if action, ok := txRegistry[module].actions[action]; ok {
+		return action.name
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(action))
+}
+
+func DecodeTx(module Module, action Action, data []byte) (Tx, error) {
+	entry, ok := txRegistry[module].actions[action]
+	if !ok {
 		return nil, fmt.Errorf("unrecognized goat tx(module %d action %d)", module, action)
 	}
+	inner := entry.newTx()
 	if err := inner.Decode(data); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,250 @@
+package goattypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TxInfo is the decoded goat tx attached to the RPC transaction and receipt
+// objects, the payload is the inner tx of the module and action.
+type TxInfo struct {
+	Module  string `json:"moduleName"`
+	Action  string `json:"actionName"`
+	Payload Tx     `json:"payload"`
+	Deposit *Mint  `json:"deposit,omitempty"`
+	Claim   *Mint  `json:"claim,omitempty"`
+}
+
+// NewTxInfo returns the decoded goat tx with the amounts minted by it
+func NewTxInfo(module Module, action Action, tx Tx) *TxInfo {
+	return &TxInfo{
+		Module:  module.String(),
+		Action:  ActionName(module, action),
+		Payload: tx,
+		Deposit: tx.Deposit(),
+		Claim:   tx.Claim(),
+	}
+}
+
+// UnmarshalJSON decodes the module from a JSON number or a hex quantity, the RPC
+// transaction objects show the module as a hex quantity.
+func (m *Module) UnmarshalJSON(input []byte) error {
+	v, err := unmarshalUint8JSON(input)
+	*m = Module(v)
+	return err
+}
+
+// UnmarshalJSON decodes the action from a JSON number or a hex quantity, the RPC
+// transaction objects show the action as a hex quantity.
+func (a *Action) UnmarshalJSON(input []byte) error {
+	v, err := unmarshalUint8JSON(input)
+	*a = Action(v)
+	return err
+}
+
+func unmarshalUint8JSON(input []byte) (uint8, error) {
+	if len(input) == 0 || input[0] != '"' {
+		var v uint8
+		err := json.Unmarshal(input, &v)
+		return v, err
+	}
+	var v hexutil.Uint64
+	if err := v.UnmarshalJSON(input); err != nil {
+		return 0, err
+	}
+	if v > math.MaxUint8 {
+		return 0, fmt.Errorf("goat module or action %d overflows uint8", v)
+	}
+	return uint8(v), nil
+}
+
+type mintJSON struct {
+	Address common.Address `json:"address"`
+	Amount  *hexutil.Big   `json:"amount"`
+}
+
+func (m Mint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&mintJSON{Address: m.Address, Amount: (*hexutil.Big)(m.Amount)})
+}
+
+func (m *Mint) UnmarshalJSON(input []byte) error {
+	var dec mintJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	m.Address, m.Amount = dec.Address, bigOrZero(dec.Amount)
+	return nil
+}
+
+type depositTxJSON struct {
+	Txid   common.Hash    `json:"txid"`
+	TxOut  hexutil.Uint64 `json:"txout"`
+	Target common.Address `json:"target"`
+	Amount *hexutil.Big   `json:"amount"`
+}
+
+func (tx DepositTx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&depositTxJSON{
+		Txid:   tx.Txid,
+		TxOut:  hexutil.Uint64(tx.TxOut),
+		Target: tx.Target,
+		Amount: (*hexutil.Big)(tx.Amount),
+	})
+}
+
+func (tx *DepositTx) UnmarshalJSON(input []byte) error {
+	var dec depositTxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	tx.Txid, tx.TxOut, tx.Target, tx.Amount = dec.Txid, uint32(dec.TxOut), dec.Target, bigOrZero(dec.Amount)
+	return nil
+}
+
+type cancel2TxJSON struct {
+	Id *hexutil.Big `json:"id"`
+}
+
+func (tx Cancel2Tx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&cancel2TxJSON{Id: (*hexutil.Big)(tx.Id)})
+}
+
+func (tx *Cancel2Tx) UnmarshalJSON(input []byte) error {
+	var dec cancel2TxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	tx.Id = bigOrZero(dec.Id)
+	return nil
+}
+
+type paidTxJSON struct {
+	Id     *hexutil.Big   `json:"id"`
+	Txid   common.Hash    `json:"txid"`
+	TxOut  hexutil.Uint64 `json:"txout"`
+	Amount *hexutil.Big   `json:"amount"`
+}
+
+func (tx PaidTx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&paidTxJSON{
+		Id:     (*hexutil.Big)(tx.Id),
+		Txid:   tx.Txid,
+		TxOut:  hexutil.Uint64(tx.TxOut),
+		Amount: (*hexutil.Big)(tx.Amount),
+	})
+}
+
+func (tx *PaidTx) UnmarshalJSON(input []byte) error {
+	var dec paidTxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	tx.Id, tx.Txid, tx.TxOut, tx.Amount = bigOrZero(dec.Id), dec.Txid, uint32(dec.TxOut), bigOrZero(dec.Amount)
+	return nil
+}
+
+type newBtcBlockTxJSON struct {
+	Hash common.Hash `json:"hash"`
+}
+
+func (tx NewBtcBlockTx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&newBtcBlockTxJSON{Hash: tx.Hash})
+}
+
+func (tx *NewBtcBlockTx) UnmarshalJSON(input []byte) error {
+	var dec newBtcBlockTxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	tx.Hash = dec.Hash
+	return nil
+}
+
+type completeUnlockTxJSON struct {
+	Id        hexutil.Uint64 `json:"id"`
+	Recipient common.Address `json:"recipient"`
+	Token     common.Address `json:"token"`
+	Amount    *hexutil.Big   `json:"amount"`
+}
+
+func (tx CompleteUnlockTx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&completeUnlockTxJSON{
+		Id:        hexutil.Uint64(tx.Id),
+		Recipient: tx.Recipient,
+		Token:     tx.Token,
+		Amount:    (*hexutil.Big)(tx.Amount),
+	})
+}
+
+func (tx *CompleteUnlockTx) UnmarshalJSON(input []byte) error {
+	var dec completeUnlockTxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	tx.Id, tx.Recipient, tx.Token, tx.Amount = uint64(dec.Id), dec.Recipient, dec.Token, bigOrZero(dec.Amount)
+	return nil
+}
+
+type distributeRewardTxJSON struct {
+	Id        hexutil.Uint64 `json:"id"`
+	Recipient common.Address `json:"recipient"`
+	Goat      *hexutil.Big   `json:"goat"`
+	GasReward *hexutil.Big   `json:"gasReward"`
+}
+
+func (tx DistributeRewardTx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&distributeRewardTxJSON{
+		Id:        hexutil.Uint64(tx.Id),
+		Recipient: tx.Recipient,
+		Goat:      (*hexutil.Big)(tx.Goat),
+		GasReward: (*hexutil.Big)(tx.GasReward),
+	})
+}
+
+func (tx *DistributeRewardTx) UnmarshalJSON(input []byte) error {
+	var dec distributeRewardTxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	tx.Id, tx.Recipient, tx.Goat, tx.GasReward = uint64(dec.Id), dec.Recipient, bigOrZero(dec.Goat), bigOrZero(dec.GasReward)
+	return nil
+}
+
+type addVoterTxJSON struct {
+	Voter  common.Address `json:"voter"`
+	Pubkey common.Hash    `json:"pubkey"`
+}
+
+func (tx AddVoterTx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&addVoterTxJSON{Voter: tx.Voter, Pubkey: tx.Pubkey})
+}
+
+func (tx *AddVoterTx) UnmarshalJSON(input []byte) error {
+	var dec addVoterTxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	tx.Voter, tx.Pubkey = dec.Voter, dec.Pubkey
+	return nil
+}
+
+type removeVoterTxJSON struct {
+	Voter common.Address `json:"voter"`
+}
+
+func (tx RemoveVoterTx) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&removeVoterTxJSON{Voter: tx.Voter})
+}
+
+func (tx *RemoveVoterTx) UnmarshalJSON(input []byte) error {
+	var dec removeVoterTxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	tx.Voter = dec.Voter
+	return nil
+}
@@ -0,0 +1,73 @@
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTxJSON(t *testing.T) {
+	addr := common.HexToAddress("0xbc10000000000000000000000000000000000001")
+	txs := []Tx{
+		&DepositTx{Txid: common.HexToHash("0x01"), TxOut: 1, Target: addr, Amount: big.NewInt(1e18)},
+		&Cancel2Tx{Id: big.NewInt(2)},
+		&PaidTx{Id: big.NewInt(3), Txid: common.HexToHash("0x02"), TxOut: 4, Amount: big.NewInt(1e8)},
+		&NewBtcBlockTx{Hash: common.HexToHash("0x03")},
+		&CompleteUnlockTx{Id: 5, Recipient: addr, Token: addr, Amount: big.NewInt(100)},
+		&DistributeRewardTx{Id: 6, Recipient: addr, Goat: big.NewInt(7), GasReward: big.NewInt(8)},
+		&AddVoterTx{Voter: addr, Pubkey: common.HexToHash("0x04")},
+		&RemoveVoterTx{Voter: addr},
+	}
+	for _, tx := range txs {
+		enc, err := json.Marshal(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := reflect.New(reflect.TypeOf(tx).Elem()).Interface().(Tx)
+		if err := json.Unmarshal(enc, got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tx) {
+			t.Errorf("json round trip mismatch: have %+v, want %+v", got, tx)
+		}
+	}
+}
+
+func TestTxNames(t *testing.T) {
+	for module, entry := range txRegistry {
+		if name := module.String(); strings.HasPrefix(name, "unknown") {
+			t.Errorf("module %d has no name", module)
+		}
+		for action := range entry.actions {
+			if name := ActionName(module, action); strings.HasPrefix(name, "unknown") {
+				t.Errorf("action %d of module %s has no name", action, module)
+			}
+		}
+	}
+	if name := ActionName(RelayerModule, 100); name != "unknown(100)" {
+		t.Errorf("unexpected name of the unknown action: %s", name)
+	}
+}
+
+func TestModuleActionJSON(t *testing.T) {
+	for _, input := range []string{`2`, `"0x2"`} {
+		var module Module
+		if err := json.Unmarshal([]byte(input), &module); err != nil || module != LockingModule {
+			t.Errorf("module %s: have %d, err %v", input, module, err)
+		}
+		var action Action
+		if err := json.Unmarshal([]byte(input), &action); err != nil || action != 2 {
+			t.Errorf("action %s: have %d, err %v", input, action, err)
+		}
+	}
+	for _, input := range []string{`256`, `"0x100"`, `"2"`} {
+		var module Module
+		if err := json.Unmarshal([]byte(input), &module); err == nil {
+			t.Errorf("module %s: expected error", input)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package goattypes
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	RelayerAddVoterAction = iota + 1
+	RelayerRemoveVoterAction
+)
+
+type AddVoterTx struct {
+	Voter  common.Address
+	Pubkey common.Hash
+}
+
+func (tx *AddVoterTx) isGoatTx() {}
+
+func (tx *AddVoterTx) Copy() Tx {
+	return &AddVoterTx{
+		Voter:  tx.Voter,
+		Pubkey: tx.Pubkey,
+	}
+}
+
+func (tx *AddVoterTx) MethodId() [4]byte {
+	// addVoter(address voter, bytes32 pubkey)
+	return [4]byte{0x70, 0x90, 0xa9, 0x43}
+}
+
+func (tx *AddVoterTx) Size() int {
+	return 68
+}
+
+func (tx *AddVoterTx) Encode() []byte {
+	b := make([]byte, 0, tx.Size())
+
+	method := tx.MethodId()
+	b = append(b, method[:]...)
+
+	b = append(b, common.LeftPadBytes(tx.Voter[:], 32)...)
+	b = append(b, tx.Pubkey[:]...)
+
+	return b
+}
+
+func (tx *AddVoterTx) Decode(input []byte) error {
+	if len(input) != tx.Size() {
+		return errors.New("invalid input data for addVoter tx")
+	}
+
+	if [4]byte(input[:4]) != tx.MethodId() {
+		return errors.New("not an addVoter tx")
+	}
+	input = input[4:]
+
+	tx.Voter = common.BytesToAddress(input[:32])
+	tx.Pubkey = common.BytesToHash(input[32:])
+	return nil
+}
+
+func (tx *AddVoterTx) Sender() common.Address {
+	return RelayerExecutor
+}
+
+func (tx *AddVoterTx) Contract() common.Address {
+	return RelayerContract
+}
+
+func (tx *AddVoterTx) Deposit() *Mint {
+	return nil
+}
+
+func (tx *AddVoterTx) Claim() *Mint {
+	return nil
+}
+
+type RemoveVoterTx struct {
+	Voter common.Address
+}
+
+func (tx *RemoveVoterTx) isGoatTx() {}
+
+func (tx *RemoveVoterTx) Copy() Tx {
+	return &RemoveVoterTx{
+		Voter: tx.Voter,
+	}
+}
+
+func (tx *RemoveVoterTx) MethodId() [4]byte {
+	// removeVoter(address voter)
+	return [4]byte{0x86, 0xc1, 0xff, 0x68}
+}
+
+func (tx *RemoveVoterTx) Size() int {
+	return 36
+}
+
+func (tx *RemoveVoterTx) Encode() []byte {
+	b := make([]byte, 0, tx.Size())
+
+	method := tx.MethodId()
+	b = append(b, method[:]...)
+
+	b = append(b, common.LeftPadBytes(tx.Voter[:], 32)...)
+
+	return b
+}
+
+func (tx *RemoveVoterTx) Decode(input []byte) error {
+	if len(input) != tx.Size() {
+		return errors.New("invalid input data for removeVoter tx")
+	}
+
+	if [4]byte(input[:4]) != tx.MethodId() {
+		return errors.New("not a removeVoter tx")
+	}
+	tx.Voter = common.BytesToAddress(input[4:])
+	return nil
+}
+
+func (tx *RemoveVoterTx) Sender() common.Address {
+	return RelayerExecutor
+}
+
+func (tx *RemoveVoterTx) Contract() common.Address {
+	return RelayerContract
+}
+
+func (tx *RemoveVoterTx) Deposit() *Mint {
+	return nil
+}
+
+func (tx *RemoveVoterTx) Claim() *Mint {
+	return nil
+}
@@ -0,0 +1,90 @@
+package goattypes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestAddVoterTx(t *testing.T) {
+	tx := &AddVoterTx{
+		Voter:  common.HexToAddress("0x5B38Da6a701c568545dCfcB03FcB875f56beddC4"),
+		Pubkey: common.HexToHash("0x9a6a4a1e50d83fcb5c0e6a0b6e5d0ab38c7a6d04e2b0c6a37e6b1cbd0a3f4c21"),
+	}
+	want := hexutil.MustDecode("0x7090a9430000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc49a6a4a1e50d83fcb5c0e6a0b6e5d0ab38c7a6d04e2b0c6a37e6b1cbd0a3f4c21")
+
+	got := tx.Encode()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AddVoterTx.Encode() = %x, want %x", got, want)
+	}
+
+	if cop := tx.Copy(); !reflect.DeepEqual(tx, cop) {
+		t.Errorf("AddVoterTx.Copy(%v) != want %v", tx, cop)
+	}
+
+	rev := new(AddVoterTx)
+	if err := rev.Decode(got); err != nil {
+		t.Errorf("AddVoterTx.Decode(): %s", err)
+	}
+	if !reflect.DeepEqual(tx, rev) {
+		t.Errorf("AddVoterTx.Decode(%v) != want %v", tx, rev)
+	}
+
+	if err := rev.Decode(got[:tx.Size()-1]); err == nil {
+		t.Errorf("AddVoterTx.Decode() should fail for short input")
+	}
+
+	if tx.Deposit() != nil || tx.Claim() != nil {
+		t.Errorf("AddVoterTx should not mint")
+	}
+
+	if tx.Sender() != RelayerExecutor {
+		t.Errorf("AddVoterTx.Sender() != RelayerExecutor")
+	}
+
+	if tx.Contract() != RelayerContract {
+		t.Errorf("AddVoterTx.Contract() != RelayerContract")
+	}
+}
+
+func TestRemoveVoterTx(t *testing.T) {
+	tx := &RemoveVoterTx{
+		Voter: common.HexToAddress("0x5B38Da6a701c568545dCfcB03FcB875f56beddC4"),
+	}
+	want := hexutil.MustDecode("0x86c1ff680000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc4")
+
+	got := tx.Encode()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RemoveVoterTx.Encode() = %x, want %x", got, want)
+	}
+
+	if cop := tx.Copy(); !reflect.DeepEqual(tx, cop) {
+		t.Errorf("RemoveVoterTx.Copy(%v) != want %v", tx, cop)
+	}
+
+	rev := new(RemoveVoterTx)
+	if err := rev.Decode(got); err != nil {
+		t.Errorf("RemoveVoterTx.Decode(): %s", err)
+	}
+	if !reflect.DeepEqual(tx, rev) {
+		t.Errorf("RemoveVoterTx.Decode(%v) != want %v", tx, rev)
+	}
+
+	if err := rev.Decode(hexutil.MustDecode("0xc19dd32000000000000000000000000000000000000000000000000000000000c64ab11e")); err == nil {
+		t.Errorf("RemoveVoterTx.Decode() should fail for another method")
+	}
+
+	if tx.Deposit() != nil || tx.Claim() != nil {
+		t.Errorf("RemoveVoterTx should not mint")
+	}
+
+	if tx.Sender() != RelayerExecutor {
+		t.Errorf("RemoveVoterTx.Sender() != RelayerExecutor")
+	}
+
+	if tx.Contract() != RelayerContract {
+		t.Errorf("RemoveVoterTx.Contract() != RelayerContract")
+	}
+}
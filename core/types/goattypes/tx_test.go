@@ -142,6 +142,39 @@ func TestTxDecode(t *testing.T) {
 				GasReward: big.NewInt(100),
 			},
 		},
+		{
+			name: "AddVoter",
+			args: args{
+				module: RelayerModule,
+				action: RelayerAddVoterAction,
+				data:   hexutil.MustDecode("0x7090a9430000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc49a6a4a1e50d83fcb5c0e6a0b6e5d0ab38c7a6d04e2b0c6a37e6b1cbd0a3f4c21"),
+			},
+			want: &AddVoterTx{
+				Voter:  common.HexToAddress("0x5B38Da6a701c568545dCfcB03FcB875f56beddC4"),
+				Pubkey: common.HexToHash("0x9a6a4a1e50d83fcb5c0e6a0b6e5d0ab38c7a6d04e2b0c6a37e6b1cbd0a3f4c21"),
+			},
+		},
+		{
+			name: "RemoveVoter",
+			args: args{
+				module: RelayerModule,
+				action: RelayerRemoveVoterAction,
+				data:   hexutil.MustDecode("0x86c1ff680000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc4"),
+			},
+			want: &RemoveVoterTx{
+				Voter: common.HexToAddress("0x5B38Da6a701c568545dCfcB03FcB875f56beddC4"),
+			},
+		},
+		{
+			name: "unknown-action",
+			args: args{
+				module: RelayerModule,
+				action: 100,
+				data:   hexutil.MustDecode("0x86c1ff680000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc4"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -156,3 +189,16 @@ func TestTxDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestModuleExecutor(t *testing.T) {
+	for module, entry := range txRegistry {
+		for action, registered := range entry.actions {
+			if sender, executor := registered.newTx().Sender(), module.Executor(); sender != executor {
+				t.Errorf("module %s action %s: sender %x, executor %x", module, ActionName(module, action), sender, executor)
+			}
+		}
+	}
+	if executor := Module(0).Executor(); executor != (common.Address{}) {
+		t.Errorf("unknown module executor: %x", executor)
+	}
+}
@@ -37,6 +37,14 @@ func (tx *Transaction) Claim() *goattypes.Mint {
 	return tx.inner.(*GoatTx).inner.Claim()
 }
 
+// AsGoatTx returns the goat tx data, nil is returned if it's not a goat tx.
+func (tx *Transaction) AsGoatTx() *GoatTx {
+	if !tx.IsGoatTx() {
+		return nil
+	}
+	return tx.inner.(*GoatTx)
+}
+
 const (
 	GoatTxType = 0x60
 )
@@ -106,6 +114,11 @@ func (tx *GoatTx) decode(input []byte) error {
 	return err
 }
 
+// Inner returns the decoded goat tx of the module and action
+func (tx *GoatTx) Inner() goattypes.Tx {
+	return tx.inner
+}
+
 func (tx *GoatTx) Sender() common.Address {
 	return tx.inner.Sender()
 }
@@ -0,0 +1,26 @@
+package eth
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/miner"
+)
+
+var errNotGoatChain = errors.New("not a goat chain")
+
+// GoatPayloadBuilds returns the recent payload build attempts of the goat chain,
+// including the execution results of the goat txs. All of the retained attempts
+// are returned if the count is not specified.
+func (api *DebugAPI) GoatPayloadBuilds(count *int) ([]*miner.GoatBuildAttempt, error) {
+	if api.eth.BlockChain().Config().Goat == nil {
+		return nil, errNotGoatChain
+	}
+	n := miner.GoatBuildHistoryLimit
+	if count != nil {
+		if *count < 0 {
+			return nil, errors.New("negative count")
+		}
+		n = *count
+	}
+	return api.eth.Miner().GoatPayloadBuilds(n), nil
+}
@@ -0,0 +1,126 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// GoatBridgeAPI provides the goat bridge lifecycle and bitcoin block APIs backed
+// by the goat bridge indexer.
+type GoatBridgeAPI struct {
+	eth *Ethereum
+}
+
+// NewGoatBridgeAPI creates a new instance of GoatBridgeAPI.
+func NewGoatBridgeAPI(eth *Ethereum) *GoatBridgeAPI {
+	return &GoatBridgeAPI{eth: eth}
+}
+
+// GoatBridgeEvent is a state change of a bridge withdrawal or deposit.
+type GoatBridgeEvent struct {
+	Kind        string         `json:"kind"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	TxHash      common.Hash    `json:"transactionHash"`
+}
+
+// GoatBridgeLifecycle is the state changes of a bridge withdrawal or deposit
+// up to the indexed block.
+type GoatBridgeLifecycle struct {
+	Events       []*GoatBridgeEvent `json:"events"`
+	IndexedBlock hexutil.Uint64     `json:"indexedBlock"`
+}
+
+// GetWithdrawal returns the state changes of the bridge withdrawal, nil is
+// returned if the withdrawal is not found in the indexed blocks.
+func (api *GoatBridgeAPI) GetWithdrawal(id hexutil.Uint64) *GoatBridgeLifecycle {
+	return api.lifecycle(rawdb.ReadGoatWithdrawalEvents(api.eth.ChainDb(), uint64(id)))
+}
+
+// GetDeposit returns the state changes of the bridge deposit, nil is returned
+// if the deposit is not found in the indexed blocks.
+func (api *GoatBridgeAPI) GetDeposit(txid common.Hash, txout uint32) *GoatBridgeLifecycle {
+	return api.lifecycle(rawdb.ReadGoatDepositEvents(api.eth.ChainDb(), txid, txout))
+}
+
+// BtcBlock returns the bitcoin block hash fed into the bitcoin contract at the
+// given height, nil is returned if it's not found in the indexed blocks.
+func (api *GoatBridgeAPI) BtcBlock(height hexutil.Uint64) *goattypes.BtcBlock {
+	indexed, ok := api.indexedBlock()
+	if !ok {
+		return nil
+	}
+	return api.btcBlock(uint64(height), indexed)
+}
+
+// BtcTip returns the latest bitcoin block hash fed into the bitcoin contract in
+// the indexed blocks.
+func (api *GoatBridgeAPI) BtcTip() *goattypes.BtcBlock {
+	indexed, ok := api.indexedBlock()
+	if !ok {
+		return nil
+	}
+	tip := rawdb.ReadGoatBtcTip(api.eth.ChainDb())
+	if tip == nil {
+		return nil
+	}
+	// The tip of a reorged chain is kept until the new chain feeds a bitcoin
+	// block, walk back to the latest one in the canonical chain.
+	for height := *tip; rawdb.ReadGoatBtcBlock(api.eth.ChainDb(), height) != nil; height-- {
+		if block := api.btcBlock(height, indexed); block != nil {
+			return block
+		}
+	}
+	return nil
+}
+
+func (api *GoatBridgeAPI) btcBlock(height, indexed uint64) *goattypes.BtcBlock {
+	record := rawdb.ReadGoatBtcBlock(api.eth.ChainDb(), height)
+	if record == nil || !api.isIndexedCanonical(record.BlockNumber, record.BlockHash, indexed) {
+		return nil
+	}
+	return goattypes.NewBtcBlock(height, record)
+}
+
+// indexedBlock returns the last block processed by the bridge indexer
+func (api *GoatBridgeAPI) indexedBlock() (uint64, bool) {
+	sections, _, _ := api.eth.goatBridgeIndexer.Sections()
+	if sections == 0 {
+		return 0, false
+	}
+	return sections*params.GoatBridgeIndexBlocks - 1, true
+}
+
+// isIndexedCanonical reports whether the block is indexed and in the canonical chain,
+// the records of the reorged blocks are kept until they're re-indexed.
+func (api *GoatBridgeAPI) isIndexedCanonical(number uint64, hash common.Hash, indexed uint64) bool {
+	return number <= indexed && rawdb.ReadCanonicalHash(api.eth.ChainDb(), number) == hash
+}
+
+func (api *GoatBridgeAPI) lifecycle(events []goattypes.BridgeEvent) *GoatBridgeLifecycle {
+	indexed, ok := api.indexedBlock()
+	if !ok {
+		return nil
+	}
+
+	var result []*GoatBridgeEvent
+	for _, event := range events {
+		// Skip the events of the reorged blocks which are not re-indexed yet
+		if !api.isIndexedCanonical(event.BlockNumber, event.BlockHash, indexed) {
+			continue
+		}
+		result = append(result, &GoatBridgeEvent{
+			Kind:        event.Kind.String(),
+			BlockNumber: hexutil.Uint64(event.BlockNumber),
+			BlockHash:   event.BlockHash,
+			TxHash:      event.TxHash,
+		})
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return &GoatBridgeLifecycle{Events: result, IndexedBlock: hexutil.Uint64(indexed)}
+}
@@ -0,0 +1,152 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// GoatLockingAPI provides the validator APIs backed by the goat locking indexer.
+type GoatLockingAPI struct {
+	eth *Ethereum
+}
+
+// NewGoatLockingAPI creates a new instance of GoatLockingAPI.
+func NewGoatLockingAPI(eth *Ethereum) *GoatLockingAPI {
+	return &GoatLockingAPI{eth: eth}
+}
+
+// GoatValidators is the locking state of all the validators at a block.
+type GoatValidators struct {
+	BlockNumber hexutil.Uint64           `json:"blockNumber"`
+	BlockHash   common.Hash              `json:"blockHash"`
+	Validators  []*goattypes.Validator   `json:"validators"`
+	Params      *goattypes.LockingParams `json:"params"`
+}
+
+// Validator returns the locked balances, pending unlocks and claimed rewards of
+// the validator at the given block, which is the last indexed block by default.
+// Nil is returned if the validator has no locking events.
+func (api *GoatLockingAPI) Validator(ctx context.Context, address common.Address, blockNrOrHash *rpc.BlockNumberOrHash) (*goattypes.Validator, error) {
+	header, err := api.indexedHeader(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	return newGoatLockingFolder(api.eth.ChainDb(), header).validator(address)
+}
+
+// Validators returns the locking state of all the validators and the locking
+// params at the given block, which is the last indexed block by default.
+func (api *GoatLockingAPI) Validators(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash) (*GoatValidators, error) {
+	header, err := api.indexedHeader(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	var (
+		db     = api.eth.ChainDb()
+		folder = newGoatLockingFolder(db, header)
+		result = &GoatValidators{
+			BlockNumber: hexutil.Uint64(header.Number.Uint64()),
+			BlockHash:   header.Hash(),
+			Validators:  []*goattypes.Validator{},
+			Params:      goattypes.NewLockingParams(),
+		}
+	)
+	for _, address := range rawdb.ReadGoatValidators(db) {
+		validator, err := folder.validator(address)
+		if err != nil {
+			return nil, err
+		}
+		if validator != nil {
+			result.Validators = append(result.Validators, validator)
+		}
+	}
+	for _, event := range rawdb.ReadGoatLockingParamsEvents(db) {
+		if !folder.include(&event) {
+			continue
+		}
+		if err := result.Params.Apply(&event); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// indexedHeader returns the header of the given canonical block which is processed
+// by the locking indexer.
+func (api *GoatLockingAPI) indexedHeader(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash) (*types.Header, error) {
+	sections, _, _ := api.eth.goatLockingIndexer.Sections()
+	if sections == 0 {
+		return nil, errors.New("goat locking index is not ready")
+	}
+	indexed := sections*params.GoatLockingIndexBlocks - 1
+	if blockNrOrHash == nil {
+		number := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(indexed))
+		blockNrOrHash = &number
+	}
+	header, err := api.eth.APIBackend.HeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	if number := header.Number.Uint64(); number > indexed {
+		return nil, fmt.Errorf("block %d is not indexed yet, indexed block %d", number, indexed)
+	}
+	if rawdb.ReadCanonicalHash(api.eth.ChainDb(), header.Number.Uint64()) != header.Hash() {
+		return nil, errors.New("block is not in the canonical chain")
+	}
+	return header, nil
+}
+
+// goatLockingFolder folds the indexed locking events up to a canonical block.
+type goatLockingFolder struct {
+	db        ethdb.Database
+	number    uint64
+	canonical map[uint64]common.Hash
+}
+
+func newGoatLockingFolder(db ethdb.Database, header *types.Header) *goatLockingFolder {
+	return &goatLockingFolder{db: db, number: header.Number.Uint64(), canonical: make(map[uint64]common.Hash)}
+}
+
+// include reports whether the event is in the canonical chain up to the block,
+// the events of the reorged blocks are kept until they're re-indexed.
+func (f *goatLockingFolder) include(event *goattypes.LockingEvent) bool {
+	if event.BlockNumber > f.number {
+		return false
+	}
+	hash, ok := f.canonical[event.BlockNumber]
+	if !ok {
+		hash = rawdb.ReadCanonicalHash(f.db, event.BlockNumber)
+		f.canonical[event.BlockNumber] = hash
+	}
+	return hash == event.BlockHash
+}
+
+func (f *goatLockingFolder) validator(address common.Address) (*goattypes.Validator, error) {
+	var (
+		validator = goattypes.NewValidator(address)
+		found     bool
+	)
+	for _, event := range rawdb.ReadGoatValidatorEvents(f.db, address) {
+		if !f.include(&event) {
+			continue
+		}
+		if err := validator.Apply(&event); err != nil {
+			return nil, err
+		}
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return validator, nil
+}
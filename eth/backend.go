@@ -85,6 +85,9 @@ type Ethereum struct {
 	bloomIndexer      *core.ChainIndexer             // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
+	goatBridgeIndexer  *core.ChainIndexer // Goat bridge indexer, nil if it's not enabled
+	goatLockingIndexer *core.ChainIndexer // Goat locking indexer, nil if it's not enabled
+
 	APIBackend *EthAPIBackend
 
 	miner    *miner.Miner
@@ -222,6 +225,22 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 	eth.bloomIndexer.Start(eth.blockchain)
+	if config.GoatBridgeIndex {
+		if eth.blockchain.Config().Goat != nil {
+			eth.goatBridgeIndexer = core.NewGoatBridgeIndexer(chainDb, params.GoatBridgeIndexBlocks, params.GoatBridgeIndexConfirms)
+			eth.goatBridgeIndexer.Start(eth.blockchain)
+		} else {
+			log.Warn("Goat bridge index is only available on goat chains")
+		}
+	}
+	if config.GoatLockingIndex {
+		if eth.blockchain.Config().Goat != nil {
+			eth.goatLockingIndexer = core.NewGoatLockingIndexer(chainDb, params.GoatLockingIndexBlocks, params.GoatLockingIndexConfirms)
+			eth.goatLockingIndexer.Start(eth.blockchain)
+		} else {
+			log.Warn("Goat locking index is only available on goat chains")
+		}
+	}
 
 	if config.BlobPool.Datadir != "" {
 		config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
@@ -301,6 +320,13 @@ func (s *Ethereum) APIs() []rpc.API {
 	// Append any APIs exposed explicitly by the consensus engine
 	apis = append(apis, s.engine.APIs(s.BlockChain())...)
 
+	if s.goatBridgeIndexer != nil {
+		apis = append(apis, rpc.API{Namespace: "goat", Service: NewGoatBridgeAPI(s)})
+	}
+	if s.goatLockingIndexer != nil {
+		apis = append(apis, rpc.API{Namespace: "goat", Service: NewGoatLockingAPI(s)})
+	}
+
 	// Append all the local APIs and return
 	return append(apis, []rpc.API{
 		{
@@ -408,6 +434,12 @@ func (s *Ethereum) Stop() error {
 
 	// Then stop everything else.
 	s.bloomIndexer.Close()
+	if s.goatBridgeIndexer != nil {
+		s.goatBridgeIndexer.Close()
+	}
+	if s.goatLockingIndexer != nil {
+		s.goatLockingIndexer.Close()
+	}
 	close(s.closeBloomHandler)
 	s.txPool.Close()
 	s.blockchain.Stop()
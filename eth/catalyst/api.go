@@ -37,7 +37,6 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/node"
-	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/forks"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -98,6 +97,7 @@ var caps = []string{
 	"engine_newPayloadV2",
 	"engine_newPayloadV3",
 	"engine_newPayloadV4",
+	"engine_newPayloadGoatV1",
 	"engine_newPayloadWithWitnessV1",
 	"engine_newPayloadWithWitnessV2",
 	"engine_newPayloadWithWitnessV3",
@@ -437,18 +437,25 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 	// will replace it arbitrarily many times in between.
 	if payloadAttributes != nil {
 		// goat
-		if d := len(payloadAttributes.GoatTxs); d > params.GoatTxLimitPerBlock {
+		goat := api.eth.BlockChain().Config().Goat.Params(payloadAttributes.Timestamp)
+		if d := len(payloadAttributes.GoatTxs); d > goat.TxLimitPerBlock {
 			return engine.STATUS_INVALID, fmt.Errorf("goat tx size too large(size %d)", d)
 		}
+		if err := core.CheckGoatBeaconRoot(api.eth.BlockChain().Config(), payloadAttributes.Timestamp, payloadAttributes.BeaconRoot); err != nil {
+			return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(err)
+		}
 
 		goatTxs := make([]*types.Transaction, 0, len(payloadAttributes.GoatTxs))
 		for i, otx := range payloadAttributes.GoatTxs {
 			var tx = new(types.Transaction)
 			if err := tx.UnmarshalBinary(otx); err != nil {
-				return engine.STATUS_INVALID, fmt.Errorf("not a valid transaction %d: %v", i, err)
+				return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(fmt.Errorf("not a valid transaction %d: %v", i, err))
 			}
 			if !tx.IsGoatTx() {
-				return engine.STATUS_INVALID, fmt.Errorf("not a goat tx %d", i)
+				return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(fmt.Errorf("not a goat tx %d", i))
+			}
+			if err := core.CheckGoatTx(goat, tx.AsGoatTx()); err != nil {
+				return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(fmt.Errorf("goat tx %d: %w", i, err))
 			}
 			goatTxs = append(goatTxs, tx)
 		}
@@ -470,6 +477,22 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 		if api.localBlocks.has(id) {
 			return valid(&id), nil
 		}
+		// The goat txs are validated and applied before the payload building
+		// starts, so that the consensus layer gets the failing tx instead of a
+		// failed build.
+		if len(goatTxs) != 0 {
+			work, err := api.eth.Miner().PrepareGoatWork(args, payloadWitness)
+			var goatErr *miner.GoatTxError
+			if errors.As(err, &goatErr) {
+				log.Warn("Invalid goat txs in payload attributes", "parent", update.HeadBlockHash, "err", err)
+				return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(err)
+			}
+			if err != nil {
+				log.Error("Failed to prepare goat txs", "err", err)
+				return valid(nil), engine.InvalidPayloadAttributes.With(err)
+			}
+			args.GoatWork = work
+		}
 		payload, err := api.eth.Miner().BuildPayload(args, payloadWitness)
 		if err != nil {
 			log.Error("Failed to build payload", "err", err)
@@ -2,10 +2,73 @@ package catalyst
 
 import (
 	"context"
+	"errors"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
 func (api *ConsensusAPI) GetChainConfig(_ context.Context) (*params.ChainConfig, error) {
 	return api.eth.BlockChain().Config(), nil
 }
+
+// NewPayloadGoatV1 is analogous to NewPayloadV4, but the goat requests are
+// supplied decoded as the consensus layer expects. If the payload is invalid,
+// the requests diverged from the locally executed ones are reported.
+func (api *ConsensusAPI) NewPayloadGoatV1(params engine.ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash, requests *goattypes.Requests) (engine.GoatPayloadStatus, error) {
+	if api.eth.BlockChain().Config().Goat == nil {
+		return engine.GoatPayloadStatus{PayloadStatusV1: engine.PayloadStatusV1{Status: engine.INVALID}}, engine.UnsupportedFork.With(errors.New("newPayloadGoatV1 must only be called for goat payloads"))
+	}
+	if requests == nil {
+		return engine.GoatPayloadStatus{PayloadStatusV1: engine.PayloadStatusV1{Status: engine.INVALID}}, engine.InvalidParams.With(errors.New("nil goat requests"))
+	}
+	if err := requests.Validate(); err != nil {
+		return engine.GoatPayloadStatus{PayloadStatusV1: engine.PayloadStatusV1{Status: engine.INVALID}}, engine.InvalidParams.With(err)
+	}
+	encoded := goatExecutionRequests(requests)
+	executionRequests := make([]hexutil.Bytes, len(encoded))
+	for i, req := range encoded {
+		executionRequests[i] = req
+	}
+	status, err := api.NewPayloadV4(params, versionedHashes, beaconRoot, executionRequests)
+	if err != nil || status.Status != engine.INVALID {
+		return engine.GoatPayloadStatus{PayloadStatusV1: status}, err
+	}
+	return engine.GoatPayloadStatus{PayloadStatusV1: status, RequestsDiff: api.diffGoatRequests(params, requests)}, nil
+}
+
+// goatExecutionRequests encodes the goat requests as the engine api execution
+// requests, which have no type prefix.
+func goatExecutionRequests(requests *goattypes.Requests) [][]byte {
+	encoded := requests.Encode()
+	for i, req := range encoded {
+		encoded[i] = req[1:]
+	}
+	return encoded
+}
+
+// diffGoatRequests compares the goat requests with the ones processed by the
+// invalid payload when it's inserted. Nil is returned if the payload failed before
+// its requests are processed, e.g. it has an invalid tx, the validation error
+// of the payload status tells the reason then.
+func (api *ConsensusAPI) diffGoatRequests(params engine.ExecutableData, expected *goattypes.Requests) []*goattypes.RequestDiff {
+	processed, ok := api.eth.BlockChain().GoatBadBlockRequests(params.BlockHash)
+	if !ok {
+		log.Debug("Goat requests of the invalid payload are not processed", "number", params.Number, "hash", params.BlockHash)
+		return nil
+	}
+	actual, err := goattypes.NewRequests(processed)
+	if err != nil {
+		log.Error("Failed to decode the processed goat requests", "number", params.Number, "hash", params.BlockHash, "err", err)
+		return nil
+	}
+	diffs := goattypes.DiffRequests(expected, actual)
+	for _, diff := range diffs {
+		log.Warn("Diverged goat request", "number", params.Number, "hash", params.BlockHash, "type", diff.Type, "index", diff.Index)
+	}
+	return diffs
+}
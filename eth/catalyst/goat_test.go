@@ -0,0 +1,227 @@
+package catalyst
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestNewPayloadGoatV1(t *testing.T) {
+	genesis := core.DeveloperGoatGenesisBlock(30_000_000, nil)
+	node, ethService, mock := startSimulatedBeaconEthService(t, genesis, 0)
+	defer node.Close()
+
+	var (
+		api    = mock.engineAPI
+		parent = ethService.BlockChain().CurrentBlock()
+	)
+	fcState := engine.ForkchoiceStateV1{HeadBlockHash: parent.Hash(), SafeBlockHash: parent.Hash(), FinalizedBlockHash: parent.Hash()}
+	resp, err := api.forkchoiceUpdated(fcState, &engine.PayloadAttributes{
+		Timestamp:   parent.Time + 1,
+		Withdrawals: []*types.Withdrawal{},
+		BeaconRoot:  &common.Hash{},
+	}, engine.PayloadV3, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope, err := api.getPayload(*resp.PayloadID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := *envelope.ExecutionPayload
+	decode := func() *goattypes.Requests {
+		bridge, relayer, locking, err := goattypes.DecodeRequests(envelope.Requests, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &goattypes.Requests{Locking: locking, Bridge: bridge, Relayer: relayer}
+	}
+	requests := decode()
+
+	// The consensus layer expects a different gas revenue, the block hash is
+	// matched with the expected requests
+	expected := decode()
+	expected.Locking.Gas[0].Amount = big.NewInt(1)
+	block, err := engine.ExecutableDataToBlockNoHash(payload, []common.Hash{}, &common.Hash{}, goatExecutionRequests(expected))
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := payload
+	invalid.BlockHash = block.Hash()
+	status, err := api.NewPayloadGoatV1(invalid, []common.Hash{}, &common.Hash{}, expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != engine.INVALID {
+		t.Fatalf("unexpected status: have %s, want %s", status.Status, engine.INVALID)
+	}
+	if len(status.RequestsDiff) != 1 {
+		t.Fatalf("unexpected requests diff count: have %d, want 1", len(status.RequestsDiff))
+	}
+	diff := status.RequestsDiff[0]
+	if diff.Type != 0 || diff.Index != 0 || diff.Expected != expected.Locking.Gas[0] || diff.Actual.(*goattypes.GasRequest).Amount.Cmp(requests.Locking.Gas[0].Amount) != 0 {
+		t.Errorf("unexpected requests diff: %+v", diff)
+	}
+	// The known bad payload is not processed again, the diff is from its insertion
+	status, err = api.NewPayloadGoatV1(invalid, []common.Hash{}, &common.Hash{}, expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != engine.INVALID || len(status.RequestsDiff) != 1 {
+		t.Errorf("unexpected status of the known bad payload: have %s, diff %v", status.Status, status.RequestsDiff)
+	}
+
+	status, err = api.NewPayloadGoatV1(payload, []common.Hash{}, &common.Hash{}, requests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != engine.VALID || status.RequestsDiff != nil {
+		t.Errorf("unexpected status: have %s, diff %v", status.Status, status.RequestsDiff)
+	}
+
+	if _, err := api.NewPayloadGoatV1(payload, []common.Hash{}, &common.Hash{}, nil); err == nil {
+		t.Error("expected error for the nil requests")
+	}
+}
+
+func TestGoatPayloadBuilds(t *testing.T) {
+	genesis := core.DeveloperGoatGenesisBlock(30_000_000, nil)
+	node, ethService, mock := startSimulatedBeaconEthService(t, genesis, 0)
+	defer node.Close()
+
+	deposit := &goattypes.DepositTx{Txid: common.HexToHash("0x01"), TxOut: 1, Target: common.HexToAddress("0xdeadbeef"), Amount: big.NewInt(params.Ether)}
+	if err := mock.AddGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, deposit); err != nil {
+		t.Fatal(err)
+	}
+	mock.Commit()
+
+	builds := ethService.Miner().GoatPayloadBuilds(1)
+	if len(builds) != 1 {
+		t.Fatalf("unexpected build count: have %d, want 1", len(builds))
+	}
+	if build := builds[0]; build.Error != "" || build.BlockHash == nil || len(build.GoatTxs) != 1 || build.GoatTxs[0].Error != "" {
+		t.Fatalf("unexpected build attempt: %+v", build)
+	}
+
+	// The deposit is replayed, the payload is aborted by the reverted goat tx
+	replay := types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, 1, deposit))
+	parent := ethService.BlockChain().CurrentBlock()
+	if _, err := ethService.Miner().BuildPayload(&miner.BuildPayloadArgs{
+		Parent:      parent.Hash(),
+		Timestamp:   parent.Time + 1,
+		Withdrawals: []*types.Withdrawal{},
+		BeaconRoot:  &common.Hash{},
+		Version:     engine.PayloadV3,
+		GoatTxs:     types.Transactions{replay},
+	}, false); err == nil {
+		t.Fatal("expected error for the reverted goat tx")
+	}
+	builds = ethService.Miner().GoatPayloadBuilds(1)
+	if build := builds[0]; build.Error == "" || build.BlockHash != nil || len(build.GoatTxs) != 1 {
+		t.Fatalf("unexpected failed build attempt: %+v", build)
+	}
+	if result := builds[0].GoatTxs[0]; result.Error == "" || result.RevertReason == "" || result.GasUsed == 0 {
+		t.Errorf("unexpected goat tx result: %+v", result)
+	}
+}
+
+func TestGoatTxPreValidation(t *testing.T) {
+	genesis := core.DeveloperGoatGenesisBlock(30_000_000, nil)
+	node, ethService, mock := startSimulatedBeaconEthService(t, genesis, 0)
+	defer node.Close()
+
+	newDeposit := func(txid byte) *goattypes.DepositTx {
+		return &goattypes.DepositTx{Txid: common.Hash{txid}, TxOut: 1, Target: common.HexToAddress("0xdeadbeef"), Amount: big.NewInt(params.Ether)}
+	}
+	if err := mock.AddGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, newDeposit(1)); err != nil {
+		t.Fatal(err)
+	}
+	mock.Commit()
+
+	var (
+		parent  = ethService.BlockChain().CurrentBlock()
+		fcState = engine.ForkchoiceStateV1{HeadBlockHash: parent.Hash(), SafeBlockHash: parent.Hash(), FinalizedBlockHash: parent.Hash()}
+		builds  = len(ethService.Miner().GoatPayloadBuilds(miner.GoatBuildHistoryLimit))
+	)
+	encode := func(nonce uint64, tx goattypes.Tx) hexutil.Bytes {
+		enc, _ := types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, nonce, tx)).MarshalBinary()
+		return enc
+	}
+	for name, c := range map[string]struct {
+		txs    []hexutil.Bytes
+		reason string
+	}{
+		"nonce too low":  {[]hexutil.Bytes{encode(0, newDeposit(2))}, "goat tx 0: nonce too low"},
+		"nonce too high": {[]hexutil.Bytes{encode(1, newDeposit(2)), encode(3, newDeposit(3))}, "goat tx 1: nonce too high"},
+		"reverted":       {[]hexutil.Bytes{encode(1, newDeposit(2)), encode(2, newDeposit(1))}, "goat tx 1: goat tx reverted"},
+		"duplicated":     {[]hexutil.Bytes{encode(1, newDeposit(2)), encode(2, newDeposit(2))}, "goat tx 1: goat tx reverted"},
+	} {
+		_, err := mock.engineAPI.forkchoiceUpdated(fcState, &engine.PayloadAttributes{
+			Timestamp:   parent.Time + 1,
+			Withdrawals: []*types.Withdrawal{},
+			BeaconRoot:  &common.Hash{},
+			GoatTxs:     c.txs,
+		}, engine.PayloadV3, false)
+		apiErr, ok := err.(*engine.EngineAPIError)
+		if !ok || apiErr.ErrorCode() != engine.InvalidPayloadAttributes.ErrorCode() {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		if data, _ := json.Marshal(apiErr.ErrorData()); !strings.Contains(string(data), c.reason) {
+			t.Errorf("%s: unexpected error data %s, want %q", name, data, c.reason)
+		}
+	}
+	// The rejected attempts are recorded without the payloads
+	attempts := ethService.Miner().GoatPayloadBuilds(miner.GoatBuildHistoryLimit)
+	if len(attempts) != builds+4 {
+		t.Fatalf("build attempts mismatch: have %d, want %d", len(attempts), builds+4)
+	}
+	for _, attempt := range attempts[builds:] {
+		if attempt.BlockHash != nil || attempt.Error == "" {
+			t.Errorf("unexpected rejected attempt %+v", attempt)
+		}
+	}
+
+	resp, err := mock.engineAPI.forkchoiceUpdated(fcState, &engine.PayloadAttributes{
+		Timestamp:   parent.Time + 1,
+		Withdrawals: []*types.Withdrawal{},
+		BeaconRoot:  &common.Hash{},
+		GoatTxs:     []hexutil.Bytes{encode(1, newDeposit(2)), encode(2, newDeposit(3))},
+	}, engine.PayloadV3, false)
+	if err != nil || resp.PayloadID == nil {
+		t.Fatalf("unexpected result for the valid goat txs: %v", err)
+	}
+
+	// The goat txs are executed once, the empty and full payloads share the results
+	var payloads []*miner.GoatBuildAttempt
+	for deadline := time.Now().Add(5 * time.Second); len(payloads) < 2 && time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		payloads = payloads[:0]
+		for _, attempt := range ethService.Miner().GoatPayloadBuilds(miner.GoatBuildHistoryLimit)[builds+4:] {
+			if attempt.PayloadID == *resp.PayloadID {
+				payloads = append(payloads, attempt)
+			}
+		}
+	}
+	if len(payloads) < 2 {
+		t.Fatalf("missing the full payload build: have %d attempts", len(payloads))
+	}
+	if !payloads[0].Empty || payloads[1].Empty {
+		t.Fatalf("unexpected build attempts: %+v %+v", payloads[0], payloads[1])
+	}
+	for i, result := range payloads[1].GoatTxs {
+		if result != payloads[0].GoatTxs[i] {
+			t.Errorf("goat tx %d is executed again in the full payload", i)
+		}
+	}
+}
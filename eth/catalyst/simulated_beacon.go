@@ -88,6 +88,7 @@ type SimulatedBeacon struct {
 	eth         *eth.Ethereum
 	period      uint64
 	withdrawals withdrawalQueue
+	goatTxs     goatTxQueue
 
 	feeRecipient     common.Address
 	feeRecipientLock sync.Mutex // lock gates concurrent access to the feeRecipient
@@ -173,6 +174,11 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 		return fmt.Errorf("failed to sync txpool: %w", err)
 	}
 
+	goatTxs, err := c.peekGoatTxs(timestamp)
+	if err != nil {
+		return err
+	}
+
 	var random [32]byte
 	rand.Read(random[:])
 	fcResponse, err := c.engineAPI.forkchoiceUpdated(c.curForkchoiceState, &engine.PayloadAttributes{
@@ -181,9 +187,10 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 		Withdrawals:           withdrawals,
 		Random:                random,
 		BeaconRoot:            &common.Hash{},
+		GoatTxs:               goatTxs,
 	}, engine.PayloadV3, false)
 	if err != nil {
-		return err
+		return c.dropRejectedGoatTx(err)
 	}
 	if fcResponse == engine.STATUS_SYNCING {
 		return errors.New("chain rewind prevented invocation of payload creation")
@@ -231,6 +238,7 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 		return err
 	}
 	c.lastBlockTime = payload.Timestamp
+	c.goatTxs.pop(len(goatTxs))
 	return nil
 }
 
@@ -18,6 +18,7 @@ package catalyst
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -42,18 +43,21 @@ func newSimulatedBeaconAPI(sim *SimulatedBeacon) *simulatedBeaconAPI {
 }
 
 // loop is the main loop for the API when it's running in period = 0 mode. It
-// ensures that block production is triggered as soon as a new withdrawal or
-// transaction is received.
+// ensures that block production is triggered as soon as a new withdrawal,
+// goat tx or transaction is received.
 func (a *simulatedBeaconAPI) loop() {
 	var (
 		newTxs    = make(chan core.NewTxsEvent)
 		newWxs    = make(chan newWithdrawalsEvent)
+		newGxs    = make(chan newGoatTxsEvent)
 		newTxsSub = a.sim.eth.TxPool().SubscribeTransactions(newTxs, true)
 		newWxsSub = a.sim.withdrawals.subscribe(newWxs)
+		newGxsSub = a.sim.goatTxs.subscribe(newGxs)
 		doCommit  = make(chan struct{}, 1)
 	)
 	defer newTxsSub.Unsubscribe()
 	defer newWxsSub.Unsubscribe()
+	defer newGxsSub.Unsubscribe()
 
 	// A background thread which signals to the simulator when to commit
 	// based on messages over doCommit.
@@ -90,12 +94,20 @@ func (a *simulatedBeaconAPI) loop() {
 			case doCommit <- struct{}{}:
 			default:
 			}
+		case <-newGxs:
+			select {
+			case doCommit <- struct{}{}:
+			default:
+			}
 		}
 	}
 }
 
 // AddWithdrawal adds a withdrawal to the pending queue.
 func (a *simulatedBeaconAPI) AddWithdrawal(ctx context.Context, withdrawal *types.Withdrawal) error {
+	if a.sim.isGoat() {
+		return errors.New("withdrawals not allowed for goat-geth")
+	}
 	return a.sim.withdrawals.add(withdrawal)
 }
 
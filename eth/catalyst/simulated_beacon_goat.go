@@ -0,0 +1,198 @@
+package catalyst
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/miner"
+)
+
+var errNotGoatChain = errors.New("not a goat chain")
+
+// pendingGoatTx is a goat tx waiting for its executor nonce to be assigned
+type pendingGoatTx struct {
+	module goattypes.Module
+	action goattypes.Action
+	tx     goattypes.Tx
+}
+
+// goatTxQueue implements a FIFO queue which holds goat txs that are pending
+// inclusion.
+type goatTxQueue struct {
+	pending []*pendingGoatTx
+	mu      sync.Mutex
+	feed    event.Feed
+	subs    event.SubscriptionScope
+}
+
+type newGoatTxsEvent struct{ Txs []goattypes.Tx }
+
+// add queues a goat tx for future inclusion.
+func (q *goatTxQueue) add(module goattypes.Module, action goattypes.Action, tx goattypes.Tx) error {
+	q.mu.Lock()
+	q.pending = append(q.pending, &pendingGoatTx{module: module, action: action, tx: tx})
+	q.mu.Unlock()
+
+	q.feed.Send(newGoatTxsEvent{[]goattypes.Tx{tx}})
+	return nil
+}
+
+// peek returns the specified number of goat txs at the head of the queue
+// without dequeuing them.
+func (q *goatTxQueue) peek(count int) []*pendingGoatTx {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	count = min(count, len(q.pending))
+	return slices.Clone(q.pending[:count])
+}
+
+// pop dequeues the specified number of goat txs from the queue.
+func (q *goatTxQueue) pop(count int) []*pendingGoatTx {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	count = min(count, len(q.pending))
+	popped := q.pending[0:count]
+	q.pending = q.pending[count:]
+
+	return popped
+}
+
+// remove drops the goat tx at the given index of the queue.
+func (q *goatTxQueue) remove(index int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if index >= 0 && index < len(q.pending) {
+		q.pending = slices.Delete(q.pending, index, index+1)
+	}
+}
+
+// subscribe allows a listener to be updated when new goat txs are added to
+// the queue.
+func (q *goatTxQueue) subscribe(ch chan<- newGoatTxsEvent) event.Subscription {
+	sub := q.feed.Subscribe(ch)
+	return q.subs.Track(sub)
+}
+
+// isGoat reports whether the simulated chain is a goat chain
+func (c *SimulatedBeacon) isGoat() bool {
+	return c.eth.BlockChain().Config().Goat != nil
+}
+
+// AddGoatTx queues a goat tx for inclusion in the next block, the executor
+// nonce is assigned at sealing time.
+func (c *SimulatedBeacon) AddGoatTx(module goattypes.Module, action goattypes.Action, tx goattypes.Tx) error {
+	if !c.isGoat() {
+		return errNotGoatChain
+	}
+	return c.goatTxs.add(module, action, tx)
+}
+
+// peekGoatTxs returns the queued goat txs for the block at the given timestamp
+// and assigns the executor nonces on top of the current head state. The txs stay
+// in the queue until the block is sealed, so that a failed sealing keeps them for
+// the next block.
+func (c *SimulatedBeacon) peekGoatTxs(timestamp uint64) ([]hexutil.Bytes, error) {
+	if !c.isGoat() {
+		return nil, nil
+	}
+	limit := c.eth.BlockChain().Config().Goat.Params(timestamp).TxLimitPerBlock
+	pending := c.goatTxs.peek(limit)
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	statedb, err := c.eth.BlockChain().State()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		nonces = make(map[common.Address]uint64)
+		txs    = make([]hexutil.Bytes, 0, len(pending))
+	)
+	for _, item := range pending {
+		sender := item.tx.Sender()
+		nonce, ok := nonces[sender]
+		if !ok {
+			nonce = statedb.GetNonce(sender)
+		}
+		raw, err := types.NewTx(types.NewGoatTx(item.module, item.action, nonce, item.tx)).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		nonces[sender] = nonce + 1
+		txs = append(txs, raw)
+	}
+	return txs, nil
+}
+
+// dropRejectedGoatTx drops the goat tx rejected by the payload building from the
+// queue, the others are kept for the next block. The error is returned with the
+// index of the rejected tx.
+func (c *SimulatedBeacon) dropRejectedGoatTx(err error) error {
+	var goatErr *miner.GoatTxError
+	if !errors.As(err, &goatErr) {
+		return err
+	}
+	c.goatTxs.remove(goatErr.Index)
+	return fmt.Errorf("dropped the rejected goat tx: %w", goatErr)
+}
+
+// AddDeposit queues a bridge deposit goat tx.
+func (a *simulatedBeaconAPI) AddDeposit(ctx context.Context, txid common.Hash, txout uint32, target common.Address, amount *hexutil.Big) error {
+	if amount == nil {
+		return errors.New("missing deposit amount")
+	}
+	tx := &goattypes.DepositTx{Txid: txid, TxOut: txout, Target: target, Amount: amount.ToInt()}
+	return a.sim.AddGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, tx)
+}
+
+// AddBtcBlock queues a bitcoin new block goat tx.
+func (a *simulatedBeaconAPI) AddBtcBlock(ctx context.Context, hash common.Hash) error {
+	tx := &goattypes.NewBtcBlockTx{Hash: hash}
+	return a.sim.AddGoatTx(goattypes.BirdgeModule, goattypes.BitcoinNewBlockAction, tx)
+}
+
+// AddCompleteUnlock queues a locking complete unlock goat tx.
+func (a *simulatedBeaconAPI) AddCompleteUnlock(ctx context.Context, id hexutil.Uint64, recipient, token common.Address, amount *hexutil.Big) error {
+	if amount == nil {
+		return errors.New("missing unlock amount")
+	}
+	tx := &goattypes.CompleteUnlockTx{Id: uint64(id), Recipient: recipient, Token: token, Amount: amount.ToInt()}
+	return a.sim.AddGoatTx(goattypes.LockingModule, goattypes.LockingCompleteUnlockAction, tx)
+}
+
+// AddDistributeReward queues a locking distribute reward goat tx.
+func (a *simulatedBeaconAPI) AddDistributeReward(ctx context.Context, id hexutil.Uint64, recipient common.Address, goat, gasReward *hexutil.Big) error {
+	tx := &goattypes.DistributeRewardTx{Id: uint64(id), Recipient: recipient, Goat: new(big.Int), GasReward: new(big.Int)}
+	if goat != nil {
+		tx.Goat = goat.ToInt()
+	}
+	if gasReward != nil {
+		tx.GasReward = gasReward.ToInt()
+	}
+	return a.sim.AddGoatTx(goattypes.LockingModule, goattypes.LockingDistributeRewardAction, tx)
+}
+
+// AddVoter queues a relayer add voter goat tx.
+func (a *simulatedBeaconAPI) AddVoter(ctx context.Context, voter common.Address, pubkey common.Hash) error {
+	tx := &goattypes.AddVoterTx{Voter: voter, Pubkey: pubkey}
+	return a.sim.AddGoatTx(goattypes.RelayerModule, goattypes.RelayerAddVoterAction, tx)
+}
+
+// RemoveVoter queues a relayer remove voter goat tx.
+func (a *simulatedBeaconAPI) RemoveVoter(ctx context.Context, voter common.Address) error {
+	tx := &goattypes.RemoveVoterTx{Voter: voter}
+	return a.sim.AddGoatTx(goattypes.RelayerModule, goattypes.RelayerRemoveVoterAction, tx)
+}
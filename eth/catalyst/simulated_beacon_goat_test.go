@@ -0,0 +1,128 @@
+package catalyst
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSimulatedBeaconGoatTxs(t *testing.T) {
+	genesis := core.DeveloperGoatGenesisBlock(30_000_000, nil)
+	node, ethService, mock := startSimulatedBeaconEthService(t, genesis, 0)
+	defer node.Close()
+
+	var (
+		target  = common.HexToAddress("0xdeadbeef")
+		amount  = big.NewInt(params.Ether)
+		btcHash = common.HexToHash("0x01")
+	)
+	for i := 0; i < 2; i++ {
+		deposit := &goattypes.DepositTx{Txid: common.Hash{byte(i + 1)}, TxOut: uint32(i), Target: target, Amount: amount}
+		if err := mock.AddGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, deposit); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mock.AddGoatTx(goattypes.BirdgeModule, goattypes.BitcoinNewBlockAction, &goattypes.NewBtcBlockTx{Hash: btcHash}); err != nil {
+		t.Fatal(err)
+	}
+	mock.Commit()
+
+	block := ethService.BlockChain().CurrentBlock()
+	if block.Number.Uint64() != 1 {
+		t.Fatalf("unexpected head number: have %d, want 1", block.Number)
+	}
+	if txLen := block.Extra[0]; txLen != 3 {
+		t.Fatalf("unexpected goat tx count: have %d, want 3", txLen)
+	}
+	txs := ethService.BlockChain().GetBlockByHash(block.Hash()).Transactions()
+	for i, nonce := range []uint64{0, 1, 2} {
+		if !txs[i].IsGoatTx() {
+			t.Fatalf("tx %d is not a goat tx", i)
+		}
+		if txs[i].Nonce() != nonce {
+			t.Errorf("unexpected nonce of goat tx %d: have %d, want %d", i, txs[i].Nonce(), nonce)
+		}
+	}
+	for i, receipt := range ethService.BlockChain().GetReceiptsByHash(block.Hash()) {
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			t.Errorf("goat tx %d failed", i)
+		}
+	}
+
+	state, err := ethService.BlockChain().State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce := state.GetNonce(goattypes.RelayerExecutor); nonce != 3 {
+		t.Errorf("unexpected relayer executor nonce: have %d, want 3", nonce)
+	}
+	total := new(big.Int).Add(state.GetBalance(target).ToBig(), state.GetBalance(goattypes.GoatFoundationContract).ToBig())
+	if want := new(big.Int).Mul(amount, big.NewInt(2)); total.Cmp(want) != 0 {
+		t.Errorf("unexpected deposited amount: have %s, want %s", total, want)
+	}
+
+	// The queue is drained, following blocks have no goat txs
+	mock.Commit()
+	if block := ethService.BlockChain().CurrentBlock(); block.Extra[0] != 0 {
+		t.Errorf("unexpected goat tx count: have %d, want 0", block.Extra[0])
+	}
+}
+
+func TestSimulatedBeaconRejectedGoatTx(t *testing.T) {
+	genesis := core.DeveloperGoatGenesisBlock(30_000_000, nil)
+	node, ethService, mock := startSimulatedBeaconEthService(t, genesis, 0)
+	defer node.Close()
+
+	deposit := func(txid byte) *goattypes.DepositTx {
+		return &goattypes.DepositTx{Txid: common.Hash{txid}, TxOut: 1, Target: common.HexToAddress("0xdeadbeef"), Amount: big.NewInt(params.Ether)}
+	}
+	if err := mock.AddGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, deposit(1)); err != nil {
+		t.Fatal(err)
+	}
+	mock.Commit()
+
+	// The duplicated deposit is rejected, the others stay queued for the next block
+	for _, txid := range []byte{2, 1, 3} {
+		if err := mock.AddGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, deposit(txid)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	head := ethService.BlockChain().CurrentBlock()
+	err := mock.AdjustTime(0)
+	var goatErr *miner.GoatTxError
+	if !errors.As(err, &goatErr) || goatErr.Index != 1 {
+		t.Fatalf("unexpected sealing error: %v", err)
+	}
+	if block := ethService.BlockChain().CurrentBlock(); block.Hash() != head.Hash() {
+		t.Fatalf("unexpected block %d sealed with the rejected goat tx", block.Number)
+	}
+	if err := mock.AdjustTime(0); err != nil {
+		t.Fatal(err)
+	}
+	block := ethService.BlockChain().CurrentBlock()
+	if txLen := block.Extra[0]; txLen != 2 {
+		t.Fatalf("unexpected goat tx count: have %d, want 2", txLen)
+	}
+	for i, tx := range ethService.BlockChain().GetBlockByHash(block.Hash()).Transactions() {
+		if txid := tx.AsGoatTx().Inner().(*goattypes.DepositTx).Txid; txid != (common.Hash{[]byte{2, 3}[i]}) {
+			t.Errorf("unexpected deposit %d: %x", i, txid)
+		}
+	}
+}
+
+func TestSimulatedBeaconGoatTxsNotGoat(t *testing.T) {
+	genesis := core.DeveloperGenesisBlock(30_000_000, nil)
+	node, _, mock := startSimulatedBeaconEthService(t, genesis, 0)
+	defer node.Close()
+
+	if err := mock.AddGoatTx(goattypes.BirdgeModule, goattypes.BitcoinNewBlockAction, &goattypes.NewBtcBlockTx{}); err != errNotGoatChain {
+		t.Fatalf("unexpected error: have %v, want %v", err, errNotGoatChain)
+	}
+}
@@ -121,6 +121,12 @@ type Config struct {
 	// This is the number of blocks for which logs will be cached in the filter system.
 	FilterLogCacheSize int
 
+	// Enables indexing the goat bridge withdrawals, deposits and bitcoin blocks
+	GoatBridgeIndex bool
+
+	// Enables indexing the goat locking events of the validators
+	GoatLockingIndex bool
+
 	// Mining options
 	Miner miner.Config
 
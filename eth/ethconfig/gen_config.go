@@ -39,6 +39,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		SnapshotCache           int
 		Preimages               bool
 		FilterLogCacheSize      int
+		GoatBridgeIndex         bool
+		GoatLockingIndex        bool
 		Miner                   miner.Config
 		TxPool                  legacypool.Config
 		BlobPool                blobpool.Config
@@ -76,6 +78,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.SnapshotCache = c.SnapshotCache
 	enc.Preimages = c.Preimages
 	enc.FilterLogCacheSize = c.FilterLogCacheSize
+	enc.GoatBridgeIndex = c.GoatBridgeIndex
+	enc.GoatLockingIndex = c.GoatLockingIndex
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
@@ -117,6 +121,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		SnapshotCache           *int
 		Preimages               *bool
 		FilterLogCacheSize      *int
+		GoatBridgeIndex         *bool
+		GoatLockingIndex        *bool
 		Miner                   *miner.Config
 		TxPool                  *legacypool.Config
 		BlobPool                *blobpool.Config
@@ -201,6 +207,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.FilterLogCacheSize != nil {
 		c.FilterLogCacheSize = *dec.FilterLogCacheSize
 	}
+	if dec.GoatBridgeIndex != nil {
+		c.GoatBridgeIndex = *dec.GoatBridgeIndex
+	}
+	if dec.GoatLockingIndex != nil {
+		c.GoatLockingIndex = *dec.GoatLockingIndex
+	}
 	if dec.Miner != nil {
 		c.Miner = *dec.Miner
 	}
@@ -994,27 +994,15 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 // be tracer dependent.
 func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *core.Message, txctx *Context, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceConfig) (interface{}, error) {
 	var (
-		tracer  *Tracer
-		err     error
 		timeout = defaultTraceTimeout
 		usedGas uint64
 	)
 	if config == nil {
 		config = &TraceConfig{}
 	}
-	// Default tracer is the struct logger
-	if config.Tracer == nil {
-		logger := logger.NewStructLogger(config.Config)
-		tracer = &Tracer{
-			Hooks:     logger.Hooks(),
-			GetResult: logger.GetResult,
-			Stop:      logger.Stop,
-		}
-	} else {
-		tracer, err = DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig, api.backend.ChainConfig())
-		if err != nil {
-			return nil, err
-		}
+	tracer, err := api.newTracer(txctx, config)
+	if err != nil {
+		return nil, err
 	}
 	// The actual TxContext will be created as part of ApplyTransactionWithEVM.
 	vmenv := vm.NewEVM(vmctx, vm.TxContext{GasPrice: message.GasPrice, BlobFeeCap: message.BlobGasFeeCap}, statedb, api.backend.ChainConfig(), vm.Config{Tracer: tracer.Hooks, NoBaseFee: true})
@@ -1045,6 +1033,20 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 	return tracer.GetResult()
 }
 
+// newTracer creates the tracer of the given configuration, the default tracer
+// is the struct logger.
+func (api *API) newTracer(txctx *Context, config *TraceConfig) (*Tracer, error) {
+	if config.Tracer == nil {
+		logger := logger.NewStructLogger(config.Config)
+		return &Tracer{
+			Hooks:     logger.Hooks(),
+			GetResult: logger.GetResult,
+			Stop:      logger.Stop,
+		}, nil
+	}
+	return DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig, api.backend.ChainConfig())
+}
+
 // APIs return the collection of RPC services the tracer package offers.
 func APIs(backend Backend) []rpc.API {
 	// Append all the local APIs and return
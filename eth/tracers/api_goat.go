@@ -0,0 +1,113 @@
+package tracers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TraceGoatGasFee traces the gas fee distribution at the end of the goat block,
+// which isn't a part of the per-tx results of the block tracing. The txs of the
+// block are replayed without tracing to get the state the fees are distributed on.
+func (api *API) TraceGoatGasFee(ctx context.Context, hash common.Hash, config *TraceConfig) (interface{}, error) {
+	chainConfig := api.backend.ChainConfig()
+	if chainConfig.Goat == nil {
+		return nil, errors.New("not a goat chain")
+	}
+	block, err := api.blockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	receipts := rawdb.ReadRawReceipts(api.backend.ChainDb(), block.Hash(), block.NumberU64())
+	if receipts == nil {
+		return nil, fmt.Errorf("receipts of block %#x not found", hash)
+	}
+	parent, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(block.NumberU64()-1), block.ParentHash())
+	if err != nil {
+		return nil, err
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, release, err := api.backend.StateAtBlock(ctx, parent, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	blockCtx := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		vmenv := vm.NewEVM(blockCtx, vm.TxContext{}, statedb, chainConfig, vm.Config{})
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
+	signer := types.MakeSigner(chainConfig, block.Number(), block.Time())
+	for i, tx := range block.Transactions() {
+		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
+		statedb.SetTxContext(tx.Hash(), i)
+		vmenv := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), statedb, chainConfig, vm.Config{})
+		if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.GasLimit)); err != nil {
+			return nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+		}
+		statedb.Finalise(vmenv.ChainConfig().IsEIP158(block.Number()))
+	}
+	return api.traceGoatGasFee(block, receipts, blockCtx, statedb, config)
+}
+
+// traceGoatGasFee traces the gas fee distribution of the goat block on the state
+// after the txs. It's traced as a system call carrying the gas fees of the block,
+// whose frames are the payouts to the fee recipients and the locking contract.
+func (api *API) traceGoatGasFee(block *types.Block, receipts types.Receipts, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceConfig) (interface{}, error) {
+	chainConfig := api.backend.ChainConfig()
+	record, err := core.DeriveGoatFeeRecord(chainConfig, block, receipts, statedb)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &TraceConfig{}
+	}
+	txctx := &Context{
+		BlockHash:   block.Hash(),
+		BlockNumber: block.Number(),
+		TxIndex:     len(block.Transactions()),
+	}
+	tracer, err := api.newTracer(txctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		hooks = tracer.Hooks
+		fees  = new(big.Int).Add(record.Burnt, record.Tips)
+		tx    = types.NewTx(&types.LegacyTx{To: &params.SystemAddress, Value: fees, GasPrice: common.Big0})
+		vmenv = vm.NewEVM(vmctx, vm.TxContext{Origin: params.SystemAddress, GasPrice: common.Big0}, statedb, chainConfig, vm.Config{Tracer: hooks, NoBaseFee: true})
+	)
+	statedb.SetTxContext(common.Hash{}, txctx.TxIndex)
+	if hooks.OnTxStart != nil {
+		hooks.OnTxStart(vmenv.GetVMContext(), tx, params.SystemAddress)
+	}
+	if hooks.OnEnter != nil {
+		hooks.OnEnter(0, byte(vm.CALL), params.SystemAddress, params.SystemAddress, nil, 0, fees)
+	}
+	core.ProcessGoatGasFee(chainConfig.Goat.Params(block.Time()), state.NewHookedState(statedb, hooks), record.Burnt, record.Tips)
+	if hooks.OnExit != nil {
+		hooks.OnExit(0, nil, 0, nil, false)
+	}
+	if hooks.OnTxEnd != nil {
+		hooks.OnTxEnd(&types.Receipt{Status: types.ReceiptStatusSuccessful, TransactionIndex: uint(txctx.TxIndex)}, nil)
+	}
+	return tracer.GetResult()
+}
@@ -0,0 +1,191 @@
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func init() {
+	DefaultDirectory.Register("goatBalanceTracer", newGoatBalanceTracer, false)
+}
+
+type goatBalanceChange struct {
+	Address common.Address              `json:"address"`
+	Prev    *big.Int                    `json:"prev"`
+	New     *big.Int                    `json:"new"`
+	Reason  tracing.BalanceChangeReason `json:"reason"`
+}
+
+// newGoatBalanceTracer returns a tracer which collects the goat balance changes,
+// they are made out of the evm execution and reported by the hooked state only.
+func newGoatBalanceTracer(ctx *Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*Tracer, error) {
+	var changes []goatBalanceChange
+	return &Tracer{
+		Hooks: &tracing.Hooks{
+			OnBalanceChange: func(addr common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+				switch reason {
+				case tracing.BalanceGoatDepoist, tracing.BalanceGoatDepositTax, tracing.BalanceGoatReward, tracing.BalanceGoatUnlock,
+					tracing.BalanceGoatGasTax, tracing.BalanceGoatGasRevenue:
+					changes = append(changes, goatBalanceChange{addr, prev, new, reason})
+				}
+			},
+		},
+		GetResult: func() (json.RawMessage, error) { return json.Marshal(changes) },
+		Stop:      func(err error) {},
+	}, nil
+}
+
+// newGoatTestBackend creates a test backend running the goat chain with the beacon engine.
+func newGoatTestBackend(t *testing.T, n int, gspec *core.Genesis, generator func(i int, b *core.BlockGen)) *testBackend {
+	backend := &testBackend{
+		chainConfig: gspec.Config,
+		engine:      beacon.NewFaker(),
+		chaindb:     rawdb.NewMemoryDatabase(),
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, backend.engine, n, generator)
+
+	cacheConfig := &core.CacheConfig{
+		TrieCleanLimit:    256,
+		TrieDirtyLimit:    256,
+		TrieTimeLimit:     5 * time.Minute,
+		SnapshotLimit:     0,
+		TrieDirtyDisabled: true, // Archive mode
+	}
+	chain, err := core.NewBlockChain(backend.chaindb, cacheConfig, gspec, nil, backend.engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	backend.chain = chain
+	return backend
+}
+
+func TestTraceGoatTransaction(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts  = newAccounts(3)
+		target    = accounts[1].addr
+		recipient = accounts[2].addr
+		amount    = big.NewInt(params.Ether)
+		reward    = big.NewInt(params.GWei)
+		genesis   = core.DeveloperGoatGenesisBlock(30_000_000, &accounts[0].addr)
+	)
+	locking := genesis.Alloc[goattypes.LockingContract]
+	locking.Balance = new(big.Int).Add(locking.Balance, reward)
+	genesis.Alloc[goattypes.LockingContract] = locking
+
+	var (
+		deposit, distribute common.Hash
+		gasFees             *big.Int
+		signer              = types.HomesteadSigner{}
+	)
+	backend := newGoatTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		deposit = b.AddDeposit(common.Hash{0x01}, 0, target, amount).Hash()
+		distribute = b.AddDistributeReward(0, recipient, new(big.Int), reward).Hash()
+
+		// A fee-paying tx, whose gas fees are distributed at the end of the block
+		gasPrice := new(big.Int).Add(b.BaseFee(), big.NewInt(params.GWei))
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    0,
+			To:       &target,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: gasPrice,
+		}), signer, accounts[0].key)
+		b.AddTx(tx)
+		gasFees = new(big.Int).Mul(gasPrice, big.NewInt(int64(params.TxGas)))
+	})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	tracer := "goatBalanceTracer"
+	var tests = []struct {
+		txHash common.Hash
+		expect []goatBalanceChange
+	}{
+		{
+			txHash: deposit,
+			expect: []goatBalanceChange{
+				{Address: target, Prev: new(big.Int), New: amount, Reason: tracing.BalanceGoatDepoist},
+			},
+		},
+		{
+			txHash: distribute,
+			expect: []goatBalanceChange{
+				{Address: goattypes.LockingContract, Prev: locking.Balance, New: new(big.Int).Sub(locking.Balance, reward), Reason: tracing.BalanceGoatReward},
+				{Address: recipient, Prev: new(big.Int), New: reward, Reason: tracing.BalanceGoatReward},
+			},
+		},
+	}
+	for i, tc := range tests {
+		result, err := api.TraceTransaction(context.Background(), tc.txHash, &TraceConfig{Tracer: &tracer})
+		if err != nil {
+			t.Fatalf("test %d: failed to trace transaction: %v", i, err)
+		}
+		var have []goatBalanceChange
+		if err := json.Unmarshal(result.(json.RawMessage), &have); err != nil {
+			t.Fatalf("test %d: failed to unmarshal result: %v", i, err)
+		}
+		if !reflect.DeepEqual(have, tc.expect) {
+			t.Errorf("test %d: balance changes mismatch, have %+v, want %+v", i, have, tc.expect)
+		}
+	}
+
+	// The goat txs are traced the same way in the block tracing
+	results, err := api.TraceBlockByNumber(context.Background(), rpc.BlockNumber(1), &TraceConfig{Tracer: &tracer})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	for i, tc := range tests {
+		var have []goatBalanceChange
+		if err := json.Unmarshal(results[i].Result.(json.RawMessage), &have); err != nil {
+			t.Fatalf("tx %d: failed to unmarshal result: %v", i, err)
+		}
+		if !reflect.DeepEqual(have, tc.expect) {
+			t.Errorf("tx %d: balance changes mismatch, have %+v, want %+v", i, have, tc.expect)
+		}
+	}
+
+	// The block trace results are one per tx, the gas fee distribution is traced separately
+	if len(results) != 3 {
+		t.Fatalf("block trace results mismatch, have %d, want %d", len(results), 3)
+	}
+	block := backend.chain.GetBlockByNumber(1)
+	result, err := api.TraceGoatGasFee(context.Background(), block.Hash(), &TraceConfig{Tracer: &tracer})
+	if err != nil {
+		t.Fatalf("failed to trace gas fee: %v", err)
+	}
+	var (
+		foundation = genesis.Alloc[goattypes.GoatFoundationContract].Balance
+		tax        = new(big.Int).Div(new(big.Int).Mul(gasFees, big.NewInt(params.GoatFoundationTaxV0)), big.NewInt(params.GoatMaxBasisPoints))
+		lockingBal = new(big.Int).Sub(locking.Balance, reward)
+	)
+	if foundation == nil {
+		foundation = new(big.Int)
+	}
+	want, _ := json.Marshal([]goatBalanceChange{
+		{Address: goattypes.GoatFoundationContract, Prev: foundation, New: new(big.Int).Add(foundation, tax), Reason: tracing.BalanceGoatGasTax},
+		{Address: goattypes.LockingContract, Prev: lockingBal, New: new(big.Int).Add(lockingBal, new(big.Int).Sub(gasFees, tax)), Reason: tracing.BalanceGoatGasRevenue},
+	})
+	if have := result.(json.RawMessage); string(have) != string(want) {
+		t.Errorf("gas fee balance changes mismatch, have %s, want %s", have, want)
+	}
+}
@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// GoatTransfer returns the sender and the value of a goat balance change, which
+// is transferred out of the evm execution, e.g. the bridge deposit minted to the
+// target, the gas reward paid out of the locking contract and the gas fees paid
+// by the system at the end of the block.
+//
+// The decrease of the locking contract is skipped, since the increase of the
+// recipient represents the whole transfer.
+func GoatTransfer(prevBalance, newBalance *big.Int, reason tracing.BalanceChangeReason) (from common.Address, value *big.Int, ok bool) {
+	switch reason {
+	case tracing.BalanceGoatDepoist, tracing.BalanceGoatDepositTax:
+		from = goattypes.BridgeContract
+	case tracing.BalanceGoatReward, tracing.BalanceGoatUnlock:
+		from = goattypes.LockingContract
+	case tracing.BalanceGoatGasTax, tracing.BalanceGoatGasRevenue:
+		from = params.SystemAddress
+	default:
+		return common.Address{}, nil, false
+	}
+	value = new(big.Int).Sub(newBalance, prevBalance)
+	if value.Sign() <= 0 {
+		return common.Address{}, nil, false
+	}
+	return from, value, true
+}
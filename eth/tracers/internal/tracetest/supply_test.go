@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
@@ -55,9 +56,19 @@ type supplyInfoBurn struct {
 	Misc    *hexutil.Big `json:"misc,omitempty"`
 }
 
+type supplyInfoGoat struct {
+	Deposit    *hexutil.Big `json:"deposit,omitempty"`
+	DepositTax *hexutil.Big `json:"depositTax,omitempty"`
+	GasTax     *hexutil.Big `json:"gasTax,omitempty"`
+	GasRevenue *hexutil.Big `json:"gasRevenue,omitempty"`
+	Reward     *hexutil.Big `json:"reward,omitempty"`
+	Unlock     *hexutil.Big `json:"unlock,omitempty"`
+}
+
 type supplyInfo struct {
 	Issuance *supplyInfoIssuance `json:"issuance,omitempty"`
 	Burn     *supplyInfoBurn     `json:"burn,omitempty"`
+	Goat     *supplyInfoGoat     `json:"goat,omitempty"`
 
 	// Block info
 	Number     uint64      `json:"blockNumber"`
@@ -543,6 +554,74 @@ func TestSupplySelfdestructItselfAndRevert(t *testing.T) {
 	compareAsJSON(t, expected, actual)
 }
 
+func TestSupplyGoat(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = core.DeveloperGoatGenesisBlock(30_000_000, &addr)
+		to      = common.HexToAddress("0x0d1b10d13d3c393206ff5c5136c7f86e3ad390ad")
+		deposit = big.NewInt(1e18)
+		reward  = big.NewInt(2e9)
+		unlock  = big.NewInt(3e9)
+		tip     = big.NewInt(params.GWei)
+	)
+	// Charge the deposit tax 20bp, which is capped to 0.001 goat
+	gspec.Alloc[goattypes.BridgeContract].Storage[common.HexToHash("0x04")] = common.HexToHash("0x5af3107a400000071afd498d0000000200038d7ea4c680000014")
+	depositTax := big.NewInt(1e15)
+
+	goatBlockGenerationFunc := func(b *core.BlockGen) {
+		b.SetPoS()
+
+		b.AddTx(types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, 0,
+			&goattypes.DepositTx{Txid: common.HexToHash("0x01"), TxOut: 0, Target: to, Amount: deposit})))
+		b.AddTx(types.NewTx(types.NewGoatTx(goattypes.LockingModule, goattypes.LockingDistributeRewardAction, 0,
+			&goattypes.DistributeRewardTx{Id: 0, Recipient: to, Goat: new(big.Int), GasReward: reward})))
+		b.AddTx(types.NewTx(types.NewGoatTx(goattypes.LockingModule, goattypes.LockingCompleteUnlockAction, 1,
+			&goattypes.CompleteUnlockTx{Id: 1, Recipient: to, Amount: unlock})))
+
+		tx, _ := types.SignNewTx(key, types.LatestSigner(gspec.Config), &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     0,
+			To:        &to,
+			Gas:       21000,
+			GasFeeCap: new(big.Int).Add(b.BaseFee(), tip),
+			GasTipCap: tip,
+		})
+		b.AddTx(tx)
+	}
+
+	out, chain, err := testSupplyTracer(t, gspec, goatBlockGenerationFunc)
+	if err != nil {
+		t.Fatalf("failed to test supply tracer: %v", err)
+	}
+
+	// The base fee and the tips are split between the foundation and the locking contract
+	var (
+		head   = chain.CurrentBlock()
+		fees   = new(big.Int).Mul(big.NewInt(21000), new(big.Int).Add(head.BaseFee, tip))
+		gasTax = new(big.Int).Div(new(big.Int).Mul(fees, big.NewInt(int64(params.GoatParamsV0.FoundationTax))), big.NewInt(params.GoatMaxBasisPoints))
+	)
+	expected := supplyInfo{
+		Issuance: &supplyInfoIssuance{
+			Withdrawals: (*hexutil.Big)(deposit),
+		},
+		Goat: &supplyInfoGoat{
+			Deposit:    (*hexutil.Big)(deposit),
+			DepositTax: (*hexutil.Big)(depositTax),
+			GasTax:     (*hexutil.Big)(gasTax),
+			GasRevenue: (*hexutil.Big)(new(big.Int).Sub(fees, gasTax)),
+			Reward:     (*hexutil.Big)(reward),
+			Unlock:     (*hexutil.Big)(unlock),
+		},
+		Number:     1,
+		Hash:       head.Hash(),
+		ParentHash: head.ParentHash,
+	}
+	actual := out[expected.Number]
+
+	compareAsJSON(t, expected, actual)
+}
+
 func testSupplyTracer(t *testing.T, genesis *core.Genesis, gen func(*core.BlockGen)) ([]supplyInfo, *core.BlockChain, error) {
 	var (
 		engine = beacon.New(ethash.NewFaker())
@@ -242,6 +242,8 @@ func newJsTracer(code string, ctx *tracers.Context, cfg json.RawMessage, chainCo
 			OnExit:    t.OnExit,
 			OnOpcode:  t.OnOpcode,
 			OnFault:   t.OnFault,
+
+			OnBalanceChange: t.OnBalanceChange,
 		},
 		GetResult: t.GetResult,
 		Stop:      t.Stop,
@@ -432,6 +434,20 @@ func (t *jsTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, r
 	}
 }
 
+// OnBalanceChange reports the transfers of the goat tx as call frames, since
+// the goat token is minted or paid out of the system contracts without a call.
+func (t *jsTracer) OnBalanceChange(addr common.Address, prevBalance, newBalance *big.Int, reason tracing.BalanceChangeReason) {
+	if t.err != nil || !t.traceFrame {
+		return
+	}
+	from, value, ok := internal.GoatTransfer(prevBalance, newBalance, reason)
+	if !ok {
+		return
+	}
+	t.OnEnter(1, byte(vm.CALL), from, addr, nil, 0, value)
+	t.OnExit(1, nil, 0, nil, false)
+}
+
 // GetResult calls the Javascript 'result' function and returns its value, or any accumulated error
 func (t *jsTracer) GetResult() (json.RawMessage, error) {
 	if t.err != nil {
@@ -26,7 +26,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/params"
@@ -330,3 +332,23 @@ func TestSetup(t *testing.T) {
 		t.Errorf("tracer returned wrong result. have: %s, want: \"bar\"\n", string(have))
 	}
 }
+
+func TestGoatBalanceChangeFrame(t *testing.T) {
+	tracer, err := newJsTracer("{calls: [], step: function() {}, fault: function() {}, result: function() { return this.calls; }, enter: function(frame) { this.calls.push({from: toHex(frame.getFrom()), to: toHex(frame.getTo()), value: frame.getValue().toString()}); }, exit: function(res) {}}", new(tracers.Context), nil, params.TestChainConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := common.HexToAddress("0x4a284d2835a3497e08b8b7fb30459a1c8229553d")
+	tracer.OnBalanceChange(target, new(big.Int), big.NewInt(10), tracing.BalanceGoatDepoist)
+	// non-goat balance changes are not reported as frames
+	tracer.OnBalanceChange(target, big.NewInt(10), big.NewInt(20), tracing.BalanceIncreaseRewardMineBlock)
+
+	have, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"from":"0x` + common.Bytes2Hex(goattypes.BridgeContract[:]) + `","to":"0x4a284d2835a3497e08b8b7fb30459a1c8229553d","value":"10"}]`
+	if string(have) != want {
+		t.Errorf("unexpected goat frames: have %s, want %s", have, want)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -64,9 +65,32 @@ type supplyInfoBurnMarshaling struct {
 	Misc    *hexutil.Big
 }
 
+// supplyInfoGoat is the breakdown of the goat native token flows. The gas fees
+// are not burnt on goat chains, they're paid to the foundation and the locking
+// contract instead.
+type supplyInfoGoat struct {
+	Deposit    *big.Int `json:"deposit,omitempty"`    // minted by the bridge deposits, including the tax
+	DepositTax *big.Int `json:"depositTax,omitempty"` // foundation tax from the deposits
+	GasTax     *big.Int `json:"gasTax,omitempty"`     // foundation tax from the gas fees
+	GasRevenue *big.Int `json:"gasRevenue,omitempty"` // gas fees credited to the locking contract
+	Reward     *big.Int `json:"reward,omitempty"`     // gas rewards claimed from the locking contract
+	Unlock     *big.Int `json:"unlock,omitempty"`     // unlocked amounts claimed from the locking contract
+}
+
+//go:generate go run github.com/fjl/gencodec -type supplyInfoGoat -field-override supplyInfoGoatMarshaling -out gen_supplyinfogoat.go
+type supplyInfoGoatMarshaling struct {
+	Deposit    *hexutil.Big
+	DepositTax *hexutil.Big
+	GasTax     *hexutil.Big
+	GasRevenue *hexutil.Big
+	Reward     *hexutil.Big
+	Unlock     *hexutil.Big
+}
+
 type supplyInfo struct {
 	Issuance *supplyInfoIssuance `json:"issuance,omitempty"`
 	Burn     *supplyInfoBurn     `json:"burn,omitempty"`
+	Goat     *supplyInfoGoat     `json:"goat,omitempty"`
 
 	// Block info
 	Number     uint64      `json:"blockNumber"`
@@ -83,6 +107,8 @@ type supplyTracer struct {
 	delta       supplyInfo
 	txCallstack []supplyTxCallstack // Callstack for current transaction
 	logger      *lumberjack.Logger
+
+	goat bool // whether the chain is a goat chain
 }
 
 type supplyTracerConfig struct {
@@ -112,14 +138,15 @@ func newSupplyTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
 		logger: logger,
 	}
 	return &tracing.Hooks{
-		OnBlockStart:    t.onBlockStart,
-		OnBlockEnd:      t.onBlockEnd,
-		OnGenesisBlock:  t.onGenesisBlock,
-		OnTxStart:       t.onTxStart,
-		OnBalanceChange: t.onBalanceChange,
-		OnEnter:         t.onEnter,
-		OnExit:          t.onExit,
-		OnClose:         t.onClose,
+		OnBlockchainInit: t.onBlockchainInit,
+		OnBlockStart:     t.onBlockStart,
+		OnBlockEnd:       t.onBlockEnd,
+		OnGenesisBlock:   t.onGenesisBlock,
+		OnTxStart:        t.onTxStart,
+		OnBalanceChange:  t.onBalanceChange,
+		OnEnter:          t.onEnter,
+		OnExit:           t.onExit,
+		OnClose:          t.onClose,
 	}, nil
 }
 
@@ -135,6 +162,14 @@ func newSupplyInfo() supplyInfo {
 			Blob:    big.NewInt(0),
 			Misc:    big.NewInt(0),
 		},
+		Goat: &supplyInfoGoat{
+			Deposit:    big.NewInt(0),
+			DepositTax: big.NewInt(0),
+			GasTax:     big.NewInt(0),
+			GasRevenue: big.NewInt(0),
+			Reward:     big.NewInt(0),
+			Unlock:     big.NewInt(0),
+		},
 
 		Number:     0,
 		Hash:       common.Hash{},
@@ -146,6 +181,10 @@ func (s *supplyTracer) resetDelta() {
 	s.delta = newSupplyInfo()
 }
 
+func (s *supplyTracer) onBlockchainInit(chainConfig *params.ChainConfig) {
+	s.goat = chainConfig.Goat != nil
+}
+
 func (s *supplyTracer) onBlockStart(ev tracing.BlockEvent) {
 	s.resetDelta()
 
@@ -153,6 +192,12 @@ func (s *supplyTracer) onBlockStart(ev tracing.BlockEvent) {
 	s.delta.Hash = ev.Block.Hash()
 	s.delta.ParentHash = ev.Block.ParentHash()
 
+	// The gas fees of goat chains are tracked by the balance changes of the
+	// foundation and the locking contract.
+	if s.goat {
+		return
+	}
+
 	// Calculate Burn for this block
 	if ev.Block.BaseFee() != nil {
 		burn := new(big.Int).Mul(new(big.Int).SetUint64(ev.Block.GasUsed()), ev.Block.BaseFee())
@@ -206,6 +251,24 @@ func (s *supplyTracer) onBalanceChange(a common.Address, prevBalance, newBalance
 	case tracing.BalanceGoatDepoist:
 		// deposit from L1
 		s.delta.Issuance.Withdrawals.Add(s.delta.Issuance.Withdrawals, diff)
+		s.delta.Goat.Deposit.Add(s.delta.Goat.Deposit, diff)
+	case tracing.BalanceGoatDepositTax:
+		s.delta.Issuance.Withdrawals.Add(s.delta.Issuance.Withdrawals, diff)
+		s.delta.Goat.Deposit.Add(s.delta.Goat.Deposit, diff)
+		s.delta.Goat.DepositTax.Add(s.delta.Goat.DepositTax, diff)
+	case tracing.BalanceGoatGasTax:
+		s.delta.Goat.GasTax.Add(s.delta.Goat.GasTax, diff)
+	case tracing.BalanceGoatGasRevenue:
+		s.delta.Goat.GasRevenue.Add(s.delta.Goat.GasRevenue, diff)
+	case tracing.BalanceGoatReward:
+		// count the decrease of the locking contract only
+		if diff.Sign() < 0 {
+			s.delta.Goat.Reward.Sub(s.delta.Goat.Reward, diff)
+		}
+	case tracing.BalanceGoatUnlock:
+		if diff.Sign() < 0 {
+			s.delta.Goat.Unlock.Sub(s.delta.Goat.Unlock, diff)
+		}
 	default:
 		return
 	}
@@ -316,6 +379,35 @@ func (s *supplyTracer) write(data any) {
 		supply.Burn = nil
 	}
 
+	if supply.Goat.Deposit.Sign() == 0 {
+		supply.Goat.Deposit = nil
+	}
+
+	if supply.Goat.DepositTax.Sign() == 0 {
+		supply.Goat.DepositTax = nil
+	}
+
+	if supply.Goat.GasTax.Sign() == 0 {
+		supply.Goat.GasTax = nil
+	}
+
+	if supply.Goat.GasRevenue.Sign() == 0 {
+		supply.Goat.GasRevenue = nil
+	}
+
+	if supply.Goat.Reward.Sign() == 0 {
+		supply.Goat.Reward = nil
+	}
+
+	if supply.Goat.Unlock.Sign() == 0 {
+		supply.Goat.Unlock = nil
+	}
+
+	if supply.Goat.Deposit == nil && supply.Goat.DepositTax == nil && supply.Goat.GasTax == nil &&
+		supply.Goat.GasRevenue == nil && supply.Goat.Reward == nil && supply.Goat.Unlock == nil {
+		supply.Goat = nil
+	}
+
 	out, _ := json.Marshal(supply)
 	if _, err := s.logger.Write(out); err != nil {
 		log.Warn("failed to write to supply tracer log file", "error", err)
@@ -27,9 +27,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/internal"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -139,6 +139,8 @@ func newCallTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *param
 			OnEnter:   t.OnEnter,
 			OnExit:    t.OnExit,
 			OnLog:     t.OnLog,
+
+			OnBalanceChange: t.OnBalanceChange,
 		},
 		GetResult: t.GetResult,
 		Stop:      t.Stop,
@@ -179,32 +181,6 @@ func (t *callTracer) OnEnter(depth int, typ byte, from common.Address, to common
 		call.Gas = t.gasLimit
 	}
 
-	if from == goattypes.LockingExecutor && to == goattypes.LockingContract && len(input) > 4 {
-		CompleteUnlockTx := new(goattypes.CompleteUnlockTx)
-		DistributeRewardTx := new(goattypes.DistributeRewardTx)
-
-		switch [4]byte(input[:4]) {
-		case CompleteUnlockTx.MethodId():
-			if err := CompleteUnlockTx.Decode(input); err == nil {
-				call.Calls = append(call.Calls, callFrame{
-					Type:  vm.CALL,
-					From:  goattypes.LockingContract,
-					To:    &CompleteUnlockTx.Recipient,
-					Value: CompleteUnlockTx.Amount,
-				})
-			}
-		case DistributeRewardTx.MethodId():
-			if err := DistributeRewardTx.Decode(input); err == nil {
-				call.Calls = append(call.Calls, callFrame{
-					Type:  vm.CALL,
-					From:  goattypes.LockingContract,
-					To:    &DistributeRewardTx.Recipient,
-					Value: DistributeRewardTx.GasReward,
-				})
-			}
-		}
-	}
-
 	t.callstack = append(t.callstack, call)
 }
 
@@ -261,27 +237,25 @@ func (t *callTracer) OnTxEnd(receipt *types.Receipt, err error) {
 	}
 }
 
-func (t *callTracer) OnLog(log *types.Log) {
-	if log.Address == goattypes.BridgeContract && len(log.Topics) == 3 && log.Topics[0] == goattypes.DepositEventTopic {
-		event, err := goattypes.UnpackToDepositEvent(log.Topics, log.Data)
-		if err == nil {
-			t.callstack[0].Calls = append(t.callstack[0].Calls, callFrame{
-				Type:  vm.CALL,
-				From:  goattypes.BridgeContract,
-				To:    &event.Target,
-				Value: event.Amount,
-			})
-			if event.Tax.Sign() != 0 {
-				t.callstack[0].Calls = append(t.callstack[0].Calls, callFrame{
-					Type:  vm.CALL,
-					From:  goattypes.BridgeContract,
-					To:    &goattypes.GoatFoundationContract,
-					Value: event.Tax,
-				})
-			}
-		}
+// OnBalanceChange adds the transfer frames of the goat tx, since the goat
+// token is minted or paid out of the system contracts without a call.
+func (t *callTracer) OnBalanceChange(addr common.Address, prevBalance, newBalance *big.Int, reason tracing.BalanceChangeReason) {
+	if t.config.OnlyTopCall || len(t.callstack) == 0 {
+		return
 	}
+	from, value, ok := internal.GoatTransfer(prevBalance, newBalance, reason)
+	if !ok {
+		return
+	}
+	t.callstack[0].Calls = append(t.callstack[0].Calls, callFrame{
+		Type:  vm.CALL,
+		From:  from,
+		To:    &addr,
+		Value: value,
+	})
+}
 
+func (t *callTracer) OnLog(log *types.Log) {
 	// Only logs need to be captured via opcode processing
 	if !t.config.WithLog {
 		return
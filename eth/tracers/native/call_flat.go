@@ -147,6 +147,8 @@ func newFlatCallTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *p
 			OnTxEnd:   ft.OnTxEnd,
 			OnEnter:   ft.OnEnter,
 			OnExit:    ft.OnExit,
+
+			OnBalanceChange: ft.OnBalanceChange,
 		},
 		Stop:      ft.Stop,
 		GetResult: ft.GetResult,
@@ -170,6 +172,14 @@ func (t *flatCallTracer) OnEnter(depth int, typ byte, from common.Address, to co
 	}
 }
 
+// OnBalanceChange adds the transfer frames of the goat tx.
+func (t *flatCallTracer) OnBalanceChange(addr common.Address, prevBalance, newBalance *big.Int, reason tracing.BalanceChangeReason) {
+	if t.interrupt.Load() {
+		return
+	}
+	t.tracer.OnBalanceChange(addr, prevBalance, newBalance, reason)
+}
+
 // OnExit is called when EVM exits a scope, even if the scope didn't
 // execute any code.
 func (t *flatCallTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
@@ -92,6 +92,8 @@ func newPrestateTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *p
 			OnTxStart: t.OnTxStart,
 			OnTxEnd:   t.OnTxEnd,
 			OnOpcode:  t.OnOpcode,
+
+			OnBalanceChange: t.OnBalanceChange,
 		},
 		GetResult: t.GetResult,
 		Stop:      t.Stop,
@@ -159,6 +161,26 @@ func (t *prestateTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction
 	t.lookupAccount(env.Coinbase)
 }
 
+// OnBalanceChange adds the accounts changed by goat out of the evm execution,
+// such as the deposit target, the claim recipient and the gas fee recipients.
+func (t *prestateTracer) OnBalanceChange(addr common.Address, prevBalance, newBalance *big.Int, reason tracing.BalanceChangeReason) {
+	switch reason {
+	case tracing.BalanceGoatDepoist, tracing.BalanceGoatDepositTax, tracing.BalanceGoatReward, tracing.BalanceGoatUnlock,
+		tracing.BalanceGoatGasTax, tracing.BalanceGoatGasRevenue:
+	default:
+		return
+	}
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+	t.lookupAccount(addr)
+
+	// The balance is changed already, so restore the previous one
+	acc := t.pre[addr]
+	acc.Balance = new(big.Int).Set(prevBalance)
+	acc.empty = !acc.exists()
+}
+
 func (t *prestateTracer) OnTxEnd(receipt *types.Receipt, err error) {
 	if err != nil {
 		return
@@ -0,0 +1,74 @@
+package ethclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/goatproof"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// GoatRequestsByBlock returns the goat requests of the given block.
+func (ec *Client) GoatRequestsByBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*goattypes.BlockRequests, error) {
+	var r *goattypes.BlockRequests
+	err := ec.c.CallContext(ctx, &r, "goat_getRequestsByBlock", blockNrOrHash.String())
+	if err == nil && r == nil {
+		return nil, ethereum.NotFound
+	}
+	return r, err
+}
+
+// GoatBlockRewards returns the gas fee distribution of the given block.
+func (ec *Client) GoatBlockRewards(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*goattypes.BlockRewards, error) {
+	var r *goattypes.BlockRewards
+	err := ec.c.CallContext(ctx, &r, "goat_getBlockRewards", blockNrOrHash.String())
+	if err == nil && r == nil {
+		return nil, ethereum.NotFound
+	}
+	return r, err
+}
+
+// GoatBlockRewardsByRange returns the gas fee distributions of the blocks in the
+// given range, both ends are inclusive.
+func (ec *Client) GoatBlockRewardsByRange(ctx context.Context, from, to *big.Int) ([]*goattypes.BlockRewards, error) {
+	var r []*goattypes.BlockRewards
+	err := ec.c.CallContext(ctx, &r, "goat_getBlockRewardsByRange", toBlockNumArg(from), toBlockNumArg(to))
+	return r, err
+}
+
+// GoatBtcBlock returns the bitcoin block hash fed into the bitcoin contract at
+// the given height. The goat bridge index should be enabled by the node.
+func (ec *Client) GoatBtcBlock(ctx context.Context, height uint64) (*goattypes.BtcBlock, error) {
+	var r *goattypes.BtcBlock
+	err := ec.c.CallContext(ctx, &r, "goat_btcBlock", hexutil.Uint64(height))
+	if err == nil && r == nil {
+		return nil, ethereum.NotFound
+	}
+	return r, err
+}
+
+// GoatBtcTip returns the latest bitcoin block hash fed into the bitcoin contract.
+// The goat bridge index should be enabled by the node.
+func (ec *Client) GoatBtcTip(ctx context.Context) (*goattypes.BtcBlock, error) {
+	var r *goattypes.BtcBlock
+	err := ec.c.CallContext(ctx, &r, "goat_btcTip")
+	if err == nil && r == nil {
+		return nil, ethereum.NotFound
+	}
+	return r, err
+}
+
+// GoatTxProof returns the merkle proof of the goat tx at the index of the given
+// block, which could be verified against the header extra by TxProof.Verify.
+func (ec *Client) GoatTxProof(ctx context.Context, blockHash common.Hash, index uint64) (*goatproof.TxProof, error) {
+	var r *goatproof.TxProof
+	err := ec.c.CallContext(ctx, &r, "eth_getGoatTxProof", blockHash, hexutil.Uint64(index))
+	if err == nil && r == nil {
+		return nil, ethereum.NotFound
+	}
+	return r, err
+}
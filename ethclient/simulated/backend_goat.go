@@ -0,0 +1,28 @@
+package simulated
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+)
+
+// AddGoatTx queues a goat tx which is included at the head of the next committed
+// block, the nonce of the goat executor is assigned when the block is sealed.
+// An error is returned if the backend is not configured with WithGoat.
+func (n *Backend) AddGoatTx(module goattypes.Module, action goattypes.Action, tx goattypes.Tx) error {
+	return n.beacon.AddGoatTx(module, action, tx)
+}
+
+// AddDeposit queues a bridge deposit goat tx for the next committed block.
+func (n *Backend) AddDeposit(txid common.Hash, txout uint32, target common.Address, amount *big.Int) error {
+	tx := &goattypes.DepositTx{Txid: txid, TxOut: txout, Target: target, Amount: amount}
+	return n.AddGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, tx)
+}
+
+// AddDistributeReward queues a locking distribute reward goat tx for the next
+// committed block.
+func (n *Backend) AddDistributeReward(id uint64, recipient common.Address, goat, gasReward *big.Int) error {
+	tx := &goattypes.DistributeRewardTx{Id: id, Recipient: recipient, Goat: goat, GasReward: gasReward}
+	return n.AddGoatTx(goattypes.LockingModule, goattypes.LockingDistributeRewardAction, tx)
+}
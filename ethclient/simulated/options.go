@@ -19,6 +19,7 @@ package simulated
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/node"
 )
@@ -53,3 +54,17 @@ func WithMinerMinTip(tip *big.Int) func(nodeConf *node.Config, ethConf *ethconfi
 		ethConf.Miner.GasPrice = tip
 	}
 }
+
+// WithGoat configures the simulated backend to run a goat chain with the goat
+// system contracts deployed in the genesis, the accounts of the given genesis
+// alloc are kept. The goat txs could be queued with the AddGoatTx, AddDeposit
+// and AddDistributeReward methods of the backend.
+func WithGoat() func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+	return func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		genesis := core.DeveloperGoatGenesisBlock(ethConf.Genesis.GasLimit, nil)
+		for addr, account := range ethConf.Genesis.Alloc {
+			genesis.Alloc[addr] = account
+		}
+		ethConf.Genesis = genesis
+	}
+}
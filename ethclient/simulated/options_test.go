@@ -23,8 +23,10 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -72,3 +74,56 @@ func TestWithCallGasLimitOption(t *testing.T) {
 		t.Fatalf("error mismatch: have %v, want %v", err, core.ErrIntrinsicGas)
 	}
 }
+
+// Tests that the simulator runs a goat chain and includes the queued goat txs
+// in the next committed block.
+func TestWithGoatOption(t *testing.T) {
+	sim := NewBackend(types.GenesisAlloc{
+		testAddr: {Balance: big.NewInt(10000000000000000)},
+	}, WithGoat())
+	defer sim.Close()
+
+	client := sim.Client()
+	balance, err := client.BalanceAt(context.Background(), testAddr, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve balance: %v", err)
+	}
+	if balance.Cmp(big.NewInt(10000000000000000)) != 0 {
+		t.Errorf("genesis alloc is not kept: have %v", balance)
+	}
+
+	target := common.HexToAddress("0x0d1b10d13d3c393206ff5c5136c7f86e3ad390ad")
+	if err := sim.AddDeposit(common.HexToHash("0x01"), 0, target, big.NewInt(params.Ether)); err != nil {
+		t.Fatalf("failed to add deposit: %v", err)
+	}
+	sim.Commit()
+
+	head, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve head header: %v", err)
+	}
+	extra, err := goattypes.DecodeHeaderExtra(params.AllGoatDebugChainConfig.Goat.Params(head.Time), head.Extra)
+	if err != nil {
+		t.Fatalf("failed to decode header extra: %v", err)
+	}
+	if extra.TxCount != 1 {
+		t.Fatalf("goat tx count mismatch: have %d, want 1", extra.TxCount)
+	}
+	balance, err = client.BalanceAt(context.Background(), target, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve balance: %v", err)
+	}
+	if balance.Sign() == 0 {
+		t.Errorf("deposit is not credited to the target")
+	}
+}
+
+// Tests that the goat txs are rejected by a non-goat simulator.
+func TestAddGoatTxWithoutGoat(t *testing.T) {
+	sim := NewBackend(types.GenesisAlloc{})
+	defer sim.Close()
+
+	if err := sim.AddDeposit(common.HexToHash("0x01"), 0, testAddr, big.NewInt(params.Ether)); err == nil {
+		t.Fatal("expected error for the non-goat chain")
+	}
+}
@@ -0,0 +1,478 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+)
+
+// GoatMint represents the amount minted by a goat transaction.
+type GoatMint struct {
+	mint *goattypes.Mint
+}
+
+func newGoatMint(mint *goattypes.Mint) *GoatMint {
+	if mint == nil {
+		return nil
+	}
+	return &GoatMint{mint}
+}
+
+func (m *GoatMint) Address(ctx context.Context) common.Address {
+	return m.mint.Address
+}
+
+func (m *GoatMint) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*m.mint.Amount)
+}
+
+// GoatTransaction represents the decoded payload of a goat transaction, the
+// payload fields are nil if the inner tx doesn't have them.
+type GoatTransaction struct {
+	tx *types.GoatTx
+}
+
+func (g *GoatTransaction) Module(ctx context.Context) int32 {
+	return int32(g.tx.Module)
+}
+
+func (g *GoatTransaction) ModuleName(ctx context.Context) string {
+	return g.tx.Module.String()
+}
+
+func (g *GoatTransaction) Action(ctx context.Context) int32 {
+	return int32(g.tx.Action)
+}
+
+func (g *GoatTransaction) ActionName(ctx context.Context) string {
+	return goattypes.ActionName(g.tx.Module, g.tx.Action)
+}
+
+func (g *GoatTransaction) Txid(ctx context.Context) *common.Hash {
+	switch inner := g.tx.Inner().(type) {
+	case *goattypes.DepositTx:
+		return &inner.Txid
+	case *goattypes.PaidTx:
+		return &inner.Txid
+	}
+	return nil
+}
+
+func (g *GoatTransaction) TxOut(ctx context.Context) *hexutil.Uint64 {
+	var txout uint32
+	switch inner := g.tx.Inner().(type) {
+	case *goattypes.DepositTx:
+		txout = inner.TxOut
+	case *goattypes.PaidTx:
+		txout = inner.TxOut
+	default:
+		return nil
+	}
+	ret := hexutil.Uint64(txout)
+	return &ret
+}
+
+func (g *GoatTransaction) Target(ctx context.Context) *common.Address {
+	if inner, ok := g.tx.Inner().(*goattypes.DepositTx); ok {
+		return &inner.Target
+	}
+	return nil
+}
+
+func (g *GoatTransaction) Amount(ctx context.Context) *hexutil.Big {
+	switch inner := g.tx.Inner().(type) {
+	case *goattypes.DepositTx:
+		return (*hexutil.Big)(inner.Amount)
+	case *goattypes.PaidTx:
+		return (*hexutil.Big)(inner.Amount)
+	case *goattypes.CompleteUnlockTx:
+		return (*hexutil.Big)(inner.Amount)
+	}
+	return nil
+}
+
+func (g *GoatTransaction) Id(ctx context.Context) *hexutil.Big {
+	switch inner := g.tx.Inner().(type) {
+	case *goattypes.Cancel2Tx:
+		return (*hexutil.Big)(inner.Id)
+	case *goattypes.PaidTx:
+		return (*hexutil.Big)(inner.Id)
+	case *goattypes.CompleteUnlockTx:
+		return (*hexutil.Big)(new(big.Int).SetUint64(inner.Id))
+	case *goattypes.DistributeRewardTx:
+		return (*hexutil.Big)(new(big.Int).SetUint64(inner.Id))
+	}
+	return nil
+}
+
+func (g *GoatTransaction) Recipient(ctx context.Context) *common.Address {
+	switch inner := g.tx.Inner().(type) {
+	case *goattypes.CompleteUnlockTx:
+		return &inner.Recipient
+	case *goattypes.DistributeRewardTx:
+		return &inner.Recipient
+	}
+	return nil
+}
+
+func (g *GoatTransaction) Token(ctx context.Context) *common.Address {
+	if inner, ok := g.tx.Inner().(*goattypes.CompleteUnlockTx); ok {
+		return &inner.Token
+	}
+	return nil
+}
+
+func (g *GoatTransaction) Goat(ctx context.Context) *hexutil.Big {
+	if inner, ok := g.tx.Inner().(*goattypes.DistributeRewardTx); ok {
+		return (*hexutil.Big)(inner.Goat)
+	}
+	return nil
+}
+
+func (g *GoatTransaction) GasReward(ctx context.Context) *hexutil.Big {
+	if inner, ok := g.tx.Inner().(*goattypes.DistributeRewardTx); ok {
+		return (*hexutil.Big)(inner.GasReward)
+	}
+	return nil
+}
+
+func (g *GoatTransaction) BtcBlockHash(ctx context.Context) *common.Hash {
+	if inner, ok := g.tx.Inner().(*goattypes.NewBtcBlockTx); ok {
+		return &inner.Hash
+	}
+	return nil
+}
+
+func (g *GoatTransaction) Voter(ctx context.Context) *common.Address {
+	switch inner := g.tx.Inner().(type) {
+	case *goattypes.AddVoterTx:
+		return &inner.Voter
+	case *goattypes.RemoveVoterTx:
+		return &inner.Voter
+	}
+	return nil
+}
+
+func (g *GoatTransaction) Pubkey(ctx context.Context) *common.Hash {
+	if inner, ok := g.tx.Inner().(*goattypes.AddVoterTx); ok {
+		return &inner.Pubkey
+	}
+	return nil
+}
+
+func (g *GoatTransaction) Deposit(ctx context.Context) *GoatMint {
+	return newGoatMint(g.tx.Inner().Deposit())
+}
+
+func (g *GoatTransaction) Claim(ctx context.Context) *GoatMint {
+	return newGoatMint(g.tx.Inner().Claim())
+}
+
+func (t *Transaction) Goat(ctx context.Context) *GoatTransaction {
+	tx, _ := t.resolve(ctx)
+	if tx == nil || !tx.IsGoatTx() {
+		return nil
+	}
+	return &GoatTransaction{tx.AsGoatTx()}
+}
+
+// GoatRequests represents the decoded goat requests of a block.
+type GoatRequests struct {
+	hash    common.Hash
+	bridge  goattypes.BridgeRequests
+	relayer goattypes.RelayerRequests
+	locking goattypes.LockingRequests
+}
+
+// GoatRequests returns the goat requests of the block, they are re-derived from the
+// block receipts and fee record, and checked against the requests hash in the header.
+func (b *Block) GoatRequests(ctx context.Context) (*GoatRequests, error) {
+	config := b.r.backend.ChainConfig()
+	if config.Goat == nil {
+		return nil, nil
+	}
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil || block.NumberU64() == 0 {
+		return nil, err
+	}
+	receipts, err := b.resolveReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	record, err := ethapi.GoatFeeRecord(ctx, b.r.backend, block.Header())
+	if err != nil {
+		return nil, err
+	}
+	requests, err := core.DeriveGoatRequests(block, receipts, record)
+	if err != nil {
+		return nil, err
+	}
+	hash := types.CalcRequestsHash(requests)
+	if want := block.RequestsHash(); want == nil || *want != hash {
+		return nil, fmt.Errorf("goat requests hash mismatch: have %x, want %v", hash, want)
+	}
+	bridge, relayer, locking, err := goattypes.DecodeRequests(requests, true)
+	if err != nil {
+		return nil, err
+	}
+	return &GoatRequests{hash: hash, bridge: bridge, relayer: relayer, locking: locking}, nil
+}
+
+func (r *GoatRequests) RequestsHash(ctx context.Context) common.Hash {
+	return r.hash
+}
+
+func (r *GoatRequests) Bridge(ctx context.Context) *GoatBridgeRequests {
+	return &GoatBridgeRequests{r.bridge}
+}
+
+func (r *GoatRequests) Relayer(ctx context.Context) *GoatRelayerRequests {
+	return &GoatRelayerRequests{r.relayer}
+}
+
+func (r *GoatRequests) Locking(ctx context.Context) *GoatLockingRequests {
+	return &GoatLockingRequests{r.locking}
+}
+
+// GoatBridgeRequests represents the bridge requests of a block.
+type GoatBridgeRequests struct {
+	reqs goattypes.BridgeRequests
+}
+
+func (r *GoatBridgeRequests) Withdrawals(ctx context.Context) []*GoatWithdrawal {
+	ret := make([]*GoatWithdrawal, 0, len(r.reqs.Withdraws))
+	for _, req := range r.reqs.Withdraws {
+		ret = append(ret, &GoatWithdrawal{req})
+	}
+	return ret
+}
+
+func (r *GoatBridgeRequests) ReplaceByFees(ctx context.Context) []*GoatReplaceByFee {
+	ret := make([]*GoatReplaceByFee, 0, len(r.reqs.ReplaceByFees))
+	for _, req := range r.reqs.ReplaceByFees {
+		ret = append(ret, &GoatReplaceByFee{req})
+	}
+	return ret
+}
+
+func (r *GoatBridgeRequests) Cancel1s(ctx context.Context) []hexutil.Uint64 {
+	ret := make([]hexutil.Uint64, 0, len(r.reqs.Cancel1s))
+	for _, req := range r.reqs.Cancel1s {
+		ret = append(ret, hexutil.Uint64(req.Id))
+	}
+	return ret
+}
+
+// GoatRelayerRequests represents the relayer requests of a block.
+type GoatRelayerRequests struct {
+	reqs goattypes.RelayerRequests
+}
+
+func (r *GoatRelayerRequests) Adds(ctx context.Context) []*GoatVoter {
+	ret := make([]*GoatVoter, 0, len(r.reqs.Adds))
+	for _, req := range r.reqs.Adds {
+		ret = append(ret, &GoatVoter{voter: req.Voter, pubkey: &req.Pubkey})
+	}
+	return ret
+}
+
+func (r *GoatRelayerRequests) Removes(ctx context.Context) []*GoatVoter {
+	ret := make([]*GoatVoter, 0, len(r.reqs.Removes))
+	for _, req := range r.reqs.Removes {
+		ret = append(ret, &GoatVoter{voter: req.Voter})
+	}
+	return ret
+}
+
+// GoatLockingRequests represents the locking requests of a block.
+type GoatLockingRequests struct {
+	reqs goattypes.LockingRequests
+}
+
+func (r *GoatLockingRequests) Gas(ctx context.Context) []*GoatGasReward {
+	ret := make([]*GoatGasReward, 0, len(r.reqs.Gas))
+	for _, req := range r.reqs.Gas {
+		ret = append(ret, &GoatGasReward{req})
+	}
+	return ret
+}
+
+func (r *GoatLockingRequests) Creates(ctx context.Context) []*GoatValidatorRequest {
+	ret := make([]*GoatValidatorRequest, 0, len(r.reqs.Creates))
+	for _, req := range r.reqs.Creates {
+		pubkey := hexutil.Bytes(req.Pubkey[:])
+		ret = append(ret, &GoatValidatorRequest{validator: req.Validator, pubkey: &pubkey})
+	}
+	return ret
+}
+
+func (r *GoatLockingRequests) Locks(ctx context.Context) []*GoatValidatorRequest {
+	ret := make([]*GoatValidatorRequest, 0, len(r.reqs.Locks))
+	for _, req := range r.reqs.Locks {
+		ret = append(ret, &GoatValidatorRequest{validator: req.Validator, token: &req.Token, amount: req.Amount})
+	}
+	return ret
+}
+
+func (r *GoatLockingRequests) Unlocks(ctx context.Context) []*GoatValidatorRequest {
+	ret := make([]*GoatValidatorRequest, 0, len(r.reqs.Unlocks))
+	for _, req := range r.reqs.Unlocks {
+		ret = append(ret, &GoatValidatorRequest{id: &req.Id, validator: req.Validator, recipient: &req.Recipient, token: &req.Token, amount: req.Amount})
+	}
+	return ret
+}
+
+func (r *GoatLockingRequests) Claims(ctx context.Context) []*GoatValidatorRequest {
+	ret := make([]*GoatValidatorRequest, 0, len(r.reqs.Claims))
+	for _, req := range r.reqs.Claims {
+		ret = append(ret, &GoatValidatorRequest{id: &req.Id, validator: req.Validator, recipient: &req.Recipient})
+	}
+	return ret
+}
+
+func (r *GoatLockingRequests) Grants(ctx context.Context) []hexutil.Big {
+	ret := make([]hexutil.Big, 0, len(r.reqs.Grants))
+	for _, req := range r.reqs.Grants {
+		ret = append(ret, hexutil.Big(*req.Amount))
+	}
+	return ret
+}
+
+func (r *GoatLockingRequests) UpdateTokenWeights(ctx context.Context) []*GoatTokenUpdate {
+	ret := make([]*GoatTokenUpdate, 0, len(r.reqs.UpdateWeights))
+	for _, req := range r.reqs.UpdateWeights {
+		weight := hexutil.Uint64(req.Weight)
+		ret = append(ret, &GoatTokenUpdate{token: req.Token, weight: &weight})
+	}
+	return ret
+}
+
+func (r *GoatLockingRequests) UpdateTokenThresholds(ctx context.Context) []*GoatTokenUpdate {
+	ret := make([]*GoatTokenUpdate, 0, len(r.reqs.UpdateThresholds))
+	for _, req := range r.reqs.UpdateThresholds {
+		ret = append(ret, &GoatTokenUpdate{token: req.Token, threshold: (*hexutil.Big)(req.Threshold)})
+	}
+	return ret
+}
+
+// GoatWithdrawal represents a bitcoin withdrawal request.
+type GoatWithdrawal struct {
+	req *goattypes.WithdrawalRequest
+}
+
+func (w *GoatWithdrawal) Id(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(w.req.Id)
+}
+
+func (w *GoatWithdrawal) Amount(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(w.req.Amount)
+}
+
+func (w *GoatWithdrawal) TxPrice(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(w.req.TxPrice)
+}
+
+func (w *GoatWithdrawal) Address(ctx context.Context) string {
+	return w.req.Address
+}
+
+// GoatReplaceByFee represents a bitcoin withdrawal fee bump request.
+type GoatReplaceByFee struct {
+	req *goattypes.ReplaceByFeeRequest
+}
+
+func (r *GoatReplaceByFee) Id(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(r.req.Id)
+}
+
+func (r *GoatReplaceByFee) TxPrice(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(r.req.TxPrice)
+}
+
+// GoatVoter represents a relayer voter change request.
+type GoatVoter struct {
+	voter  common.Address
+	pubkey *common.Hash
+}
+
+func (v *GoatVoter) Voter(ctx context.Context) common.Address {
+	return v.voter
+}
+
+func (v *GoatVoter) Pubkey(ctx context.Context) *common.Hash {
+	return v.pubkey
+}
+
+// GoatGasReward represents the gas revenue request of the locking contract.
+type GoatGasReward struct {
+	req *goattypes.GasRequest
+}
+
+func (g *GoatGasReward) Height(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(g.req.Height)
+}
+
+func (g *GoatGasReward) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*g.req.Amount)
+}
+
+// GoatValidatorRequest represents a validator request of the locking contract,
+// the fields are nil if the request doesn't have them.
+type GoatValidatorRequest struct {
+	id        *uint64
+	validator common.Address
+	pubkey    *hexutil.Bytes
+	recipient *common.Address
+	token     *common.Address
+	amount    *big.Int
+}
+
+func (e *GoatValidatorRequest) Id(ctx context.Context) *hexutil.Uint64 {
+	return (*hexutil.Uint64)(e.id)
+}
+
+func (e *GoatValidatorRequest) Validator(ctx context.Context) common.Address {
+	return e.validator
+}
+
+func (e *GoatValidatorRequest) Pubkey(ctx context.Context) *hexutil.Bytes {
+	return e.pubkey
+}
+
+func (e *GoatValidatorRequest) Recipient(ctx context.Context) *common.Address {
+	return e.recipient
+}
+
+func (e *GoatValidatorRequest) Token(ctx context.Context) *common.Address {
+	return e.token
+}
+
+func (e *GoatValidatorRequest) Amount(ctx context.Context) *hexutil.Big {
+	return (*hexutil.Big)(e.amount)
+}
+
+// GoatTokenUpdate represents a locking token weight or threshold update.
+type GoatTokenUpdate struct {
+	token     common.Address
+	weight    *hexutil.Uint64
+	threshold *hexutil.Big
+}
+
+func (u *GoatTokenUpdate) Token(ctx context.Context) common.Address {
+	return u.token
+}
+
+func (u *GoatTokenUpdate) Weight(ctx context.Context) *hexutil.Uint64 {
+	return u.weight
+}
+
+func (u *GoatTokenUpdate) Threshold(ctx context.Context) *hexutil.Big {
+	return u.threshold
+}
@@ -0,0 +1,100 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestGoatTransactions(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = core.DeveloperGoatGenesisBlock(30_000_000, &addr)
+		target = common.HexToAddress("0xdeadbeef")
+		stack  = createNode(t)
+	)
+	defer stack.Close()
+
+	ethBackend, err := eth.New(stack, &ethconfig.Config{
+		Genesis:        gspec,
+		NetworkId:      1337,
+		TrieCleanCache: 5,
+		TrieDirtyCache: 5,
+		TrieTimeout:    60 * time.Minute,
+		SnapshotCache:  5,
+		RPCGasCap:      1000000,
+		StateScheme:    rawdb.HashScheme,
+	})
+	if err != nil {
+		t.Fatalf("could not create eth backend: %v", err)
+	}
+	chain, _ := core.GenerateChain(gspec.Config, ethBackend.BlockChain().Genesis(), beacon.NewFaker(), ethBackend.ChainDb(), 1, func(i int, b *core.BlockGen) {
+		b.AddDeposit(common.HexToHash("0x01"), 2, target, big.NewInt(params.Ether))
+		tx, _ := types.SignNewTx(key, types.LatestSigner(gspec.Config), &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			To:        &target,
+			Gas:       params.TxGas,
+			GasFeeCap: b.BaseFee(),
+		})
+		b.AddTx(tx)
+	})
+	if _, err := ethBackend.BlockChain().InsertChain(chain); err != nil {
+		t.Fatalf("could not create import blocks: %v", err)
+	}
+	filterSystem := filters.NewFilterSystem(ethBackend.APIBackend, filters.Config{})
+	handler, err := newHandler(stack, ethBackend.APIBackend, filterSystem, []string{}, []string{})
+	if err != nil {
+		t.Fatalf("could not create graphql service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{
+			body: "{block(number: 1) { transactions(goat: true) { index goat { moduleName actionName txid txout target amount recipient deposit { address amount } } } } }",
+			want: `{"block":{"transactions":[{"index":"0x0","goat":{"moduleName":"bridge","actionName":"deposit","txid":"0x0000000000000000000000000000000000000000000000000000000000000001","txout":"0x2","target":"0x00000000000000000000000000000000deadbeef","amount":"0xde0b6b3a7640000","recipient":null,"deposit":{"address":"0x00000000000000000000000000000000deadbeef","amount":"0xde0b6b3a7640000"}}}]}}`,
+		},
+		{
+			body: "{block(number: 1) { transactions(goat: false) { index goat { module } } } }",
+			want: `{"block":{"transactions":[{"index":"0x1","goat":null}]}}`,
+		},
+		{
+			body: "{block(number: 1) { goatRequests { bridge { withdrawals { id } cancel1s } relayer { adds { voter } } locking { gas { amount } creates { validator } } } } }",
+			want: `{"block":{"goatRequests":{"bridge":{"withdrawals":[],"cancel1s":[]},"relayer":{"adds":[]},"locking":{"gas":[{"amount":"0x1060b2a40b00"}],"creates":[]}}}}`,
+		},
+		{
+			body: "{block(number: 0) { goatRequests { requestsHash } } }",
+			want: `{"block":{"goatRequests":null}}`,
+		},
+	} {
+		res := handler.Schema.Exec(context.Background(), tt.body, "", map[string]interface{}{})
+		if res.Errors != nil {
+			t.Fatalf("failed to execute query for testcase #%d: %v", i, res.Errors)
+		}
+		have, err := json.Marshal(res.Data)
+		if err != nil {
+			t.Fatalf("failed to encode graphql response for testcase #%d: %s", i, err)
+		}
+		if string(have) != tt.want {
+			t.Errorf("response unmatch for testcase #%d.\nhave:\n%s\nwant:\n%s", i, have, tt.want)
+		}
+	}
+}
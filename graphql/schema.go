@@ -89,6 +89,45 @@ const schema string = `
         amount: Long!
     }
 
+    # GoatMint is the amount minted by a goat transaction.
+    type GoatMint {
+        address: Address!
+        amount: BigInt!
+    }
+
+    # GoatTransaction is the decoded payload of a goat transaction, which is
+    # injected by the consensus layer at the head of a goat block. The payload
+    # fields are null if the goat transaction doesn't have them.
+    type GoatTransaction {
+        # Module is the goat module, 1 is bridge, 2 is locking and 3 is relayer.
+        module: Int!
+        moduleName: String!
+        # Action is the action of the goat module.
+        action: Int!
+        actionName: String!
+        # Txid and txout are the bitcoin outpoint of a deposit or a paid withdrawal.
+        txid: Bytes32
+        txout: Long
+        # Target is the deposit recipient.
+        target: Address
+        amount: BigInt
+        # Id is the withdrawal id of the bridge txs, or the unlock and claim
+        # request id of the locking txs.
+        id: BigInt
+        recipient: Address
+        token: Address
+        goat: BigInt
+        gasReward: BigInt
+        # BtcBlockHash is the bitcoin block hash fed into the bitcoin contract.
+        btcBlockHash: Bytes32
+        voter: Address
+        pubkey: Bytes32
+        # Deposit is the amount minted to the target by a bridge deposit.
+        deposit: GoatMint
+        # Claim is the amount paid to the recipient by a locking goat transaction.
+        claim: GoatMint
+    }
+
     # Transaction is an Ethereum transaction.
     type Transaction {
         # Hash is the hash of this transaction.
@@ -170,6 +209,9 @@ const schema string = `
         rawReceipt: Bytes!
         # BlobVersionedHashes is a set of hash outputs from the blobs in the transaction.
         blobVersionedHashes: [Bytes32!]
+        # Goat is the decoded goat transaction, it's null if the transaction is
+        # not a goat transaction.
+        goat: GoatTransaction
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -192,6 +234,79 @@ const schema string = `
         topics: [[Bytes32!]!]
     }
 
+    type GoatWithdrawal {
+        id: Long!
+        # Amount is the withdrawal amount in satoshi.
+        amount: Long!
+        txPrice: Long!
+        # Address is the bitcoin address to withdraw to.
+        address: String!
+    }
+
+    type GoatReplaceByFee {
+        id: Long!
+        txPrice: Long!
+    }
+
+    type GoatBridgeRequests {
+        withdrawals: [GoatWithdrawal!]!
+        replaceByFees: [GoatReplaceByFee!]!
+        # Cancel1s is the withdrawal ids to cancel.
+        cancel1s: [Long!]!
+    }
+
+    type GoatVoter {
+        voter: Address!
+        # Pubkey is null for the removed voters.
+        pubkey: Bytes32
+    }
+
+    type GoatRelayerRequests {
+        adds: [GoatVoter!]!
+        removes: [GoatVoter!]!
+    }
+
+    type GoatGasReward {
+        height: Long!
+        amount: BigInt!
+    }
+
+    # GoatValidatorRequest is a validator request of the locking contract, the
+    # fields are null if the request doesn't have them.
+    type GoatValidatorRequest {
+        id: Long
+        validator: Address!
+        pubkey: Bytes
+        recipient: Address
+        token: Address
+        amount: BigInt
+    }
+
+    type GoatTokenUpdate {
+        token: Address!
+        weight: Long
+        threshold: BigInt
+    }
+
+    type GoatLockingRequests {
+        gas: [GoatGasReward!]!
+        creates: [GoatValidatorRequest!]!
+        locks: [GoatValidatorRequest!]!
+        unlocks: [GoatValidatorRequest!]!
+        claims: [GoatValidatorRequest!]!
+        grants: [BigInt!]!
+        updateTokenWeights: [GoatTokenUpdate!]!
+        updateTokenThresholds: [GoatTokenUpdate!]!
+    }
+
+    # GoatRequests is the goat requests generated by a block for the consensus layer.
+    type GoatRequests {
+        requestsHash: Bytes32!
+        bridge: GoatBridgeRequests!
+        relayer: GoatRelayerRequests!
+        locking: GoatLockingRequests!
+    }
+
     # Block is an Ethereum block.
     type Block {
         # Number is the number of this block, starting at 0 for the genesis block.
@@ -248,7 +363,9 @@ const schema string = `
         ommerHash: Bytes32!
         # Transactions is a list of transactions associated with this block. If
         # transactions are unavailable for this block, this field will be null.
-        transactions: [Transaction!]
+        # If goat is set, only the goat transactions or only the other transactions
+        # are returned.
+        transactions(goat: Boolean): [Transaction!]
         # TransactionAt returns the transaction at the specified index. If
         # transactions are unavailable for this block, or if the index is out of
         # bounds, this field will be null.
@@ -276,6 +393,9 @@ const schema string = `
         blobGasUsed: Long
         # ExcessBlobGas is a running total of blob gas consumed in excess of the target, prior to the block.
         excessBlobGas: Long
+        # GoatRequests is the goat requests generated by this block. It's null
+        # for the genesis block and the non-goat chains.
+        goatRequests: GoatRequests
     }
 
     # CallData represents the data associated with a local contract call.
@@ -40,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/gasestimator"
@@ -1466,6 +1467,9 @@ type RPCTransaction struct {
 	R                   *hexutil.Big      `json:"r"`
 	S                   *hexutil.Big      `json:"s"`
 	YParity             *hexutil.Uint64   `json:"yParity,omitempty"`
+	Module              *hexutil.Uint64   `json:"module,omitempty"`
+	Action              *hexutil.Uint64   `json:"action,omitempty"`
+	Goat                *goattypes.TxInfo `json:"goat,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1540,6 +1544,12 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		}
 		result.MaxFeePerBlobGas = (*hexutil.Big)(tx.BlobGasFeeCap())
 		result.BlobVersionedHashes = tx.BlobHashes()
+
+	case types.GoatTxType:
+		goatTx := tx.AsGoatTx()
+		module, action := hexutil.Uint64(goatTx.Module), hexutil.Uint64(goatTx.Action)
+		result.Module, result.Action = &module, &action
+		result.Goat = goattypes.NewTxInfo(goatTx.Module, goatTx.Action, goatTx.Inner())
 	}
 	return result
 }
@@ -1865,6 +1875,9 @@ func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber u
 		fields["blobGasUsed"] = hexutil.Uint64(receipt.BlobGasUsed)
 		fields["blobGasPrice"] = (*hexutil.Big)(receipt.BlobGasPrice)
 	}
+	if goatTx := tx.AsGoatTx(); goatTx != nil {
+		fields["goat"] = goattypes.NewTxInfo(goatTx.Module, goatTx.Action, goatTx.Inner())
+	}
 
 	// If the ContractAddress is 20 0x0 bytes, assume it is not a contract creation
 	if receipt.ContractAddress != (common.Address{}) {
@@ -0,0 +1,307 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/goatproof"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+var errNotGoatChain = errors.New("not a goat chain")
+
+// GoatAPI provides an API to access the goat specific chain data.
+type GoatAPI struct {
+	b Backend
+}
+
+// NewGoatAPI creates a new goat API.
+func NewGoatAPI(b Backend) *GoatAPI {
+	return &GoatAPI{b}
+}
+
+// GetRequestsByBlock returns the goat requests of the given block, they are
+// re-derived from the block receipts and fee record, and checked against the
+// requests hash in the header.
+func (api *GoatAPI) GetRequestsByBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*goattypes.BlockRequests, error) {
+	config := api.b.ChainConfig()
+	if config.Goat == nil {
+		return nil, errNotGoatChain
+	}
+	block, err := api.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis block has no goat requests")
+	}
+	receipts, err := api.b.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	record, err := GoatFeeRecord(ctx, api.b, block.Header())
+	if err != nil {
+		return nil, err
+	}
+	requests, err := core.DeriveGoatRequests(block, receipts, record)
+	if err != nil {
+		return nil, err
+	}
+	hash := types.CalcRequestsHash(requests)
+	if want := block.RequestsHash(); want == nil || *want != hash {
+		return nil, fmt.Errorf("goat requests hash mismatch: have %x, want %v", hash, want)
+	}
+	bridge, relayer, locking, err := goattypes.DecodeRequests(requests, true)
+	if err != nil {
+		return nil, err
+	}
+	return &goattypes.BlockRequests{
+		BlockHash:    block.Hash(),
+		BlockNumber:  hexutil.Uint64(block.NumberU64()),
+		RequestsHash: hash,
+		Locking:      locking,
+		Bridge:       bridge,
+		Relayer:      relayer,
+	}, nil
+}
+
+// maxGoatRewardsRange is the max number of blocks could be queried by
+// GetBlockRewardsByRange
+const maxGoatRewardsRange = 1024
+
+// GetBlockRewards returns the gas fee distribution of the given block, which
+// is the burnt fees and tips paid to the foundation and the locking contract.
+func (api *GoatAPI) GetBlockRewards(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*goattypes.BlockRewards, error) {
+	if api.b.ChainConfig().Goat == nil {
+		return nil, errNotGoatChain
+	}
+	header, err := api.b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	return api.blockRewards(ctx, header)
+}
+
+// GetBlockRewardsByRange returns the gas fee distributions of the blocks in
+// the given range, both ends are inclusive.
+func (api *GoatAPI) GetBlockRewardsByRange(ctx context.Context, fromBlock, toBlock rpc.BlockNumber) ([]*goattypes.BlockRewards, error) {
+	if api.b.ChainConfig().Goat == nil {
+		return nil, errNotGoatChain
+	}
+	from, err := api.b.HeaderByNumber(ctx, fromBlock)
+	if from == nil || err != nil {
+		return nil, err
+	}
+	to, err := api.b.HeaderByNumber(ctx, toBlock)
+	if to == nil || err != nil {
+		return nil, err
+	}
+	begin, end := from.Number.Uint64(), to.Number.Uint64()
+	if begin > end {
+		return nil, &invalidParamsError{message: "invalid block range"}
+	}
+	if end-begin >= maxGoatRewardsRange {
+		return nil, &invalidParamsError{message: fmt.Sprintf("block range exceeds the limit %d", maxGoatRewardsRange)}
+	}
+	result := make([]*goattypes.BlockRewards, 0, end-begin+1)
+	for number := begin; number <= end; number++ {
+		header := to
+		if number != end {
+			if header, err = api.b.HeaderByNumber(ctx, rpc.BlockNumber(number)); header == nil || err != nil {
+				return nil, fmt.Errorf("block %d not found", number)
+			}
+		}
+		rewards, err := api.blockRewards(ctx, header)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rewards)
+	}
+	return result, nil
+}
+
+// blockRewards returns the gas fee distribution of the block
+func (api *GoatAPI) blockRewards(ctx context.Context, header *types.Header) (*goattypes.BlockRewards, error) {
+	hash, number := header.Hash(), header.Number.Uint64()
+	if number == 0 {
+		return nil, errors.New("genesis block has no goat rewards")
+	}
+	record, err := GoatFeeRecord(ctx, api.b, header)
+	if err != nil {
+		return nil, err
+	}
+	return goattypes.NewBlockRewards(hash, number, record), nil
+}
+
+// GoatFeeRecord returns the stored fee record of the block. The blocks imported with
+// receipts, e.g. snap synced, have no record before the fee split fork, it's re-derived
+// from the receipts then. It could be derived after the fork only if the state after
+// the block is available, since the fee shares are read from the state.
+func GoatFeeRecord(ctx context.Context, b Backend, header *types.Header) (*goattypes.FeeRecord, error) {
+	hash, number := header.Hash(), header.Number.Uint64()
+	if record := rawdb.ReadGoatFeeRecord(b.ChainDb(), hash, number); record != nil {
+		return record, nil
+	}
+	block, err := b.BlockByHash(ctx, hash)
+	if block == nil || err != nil {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	receipts, err := b.GetReceipts(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	var state goattypes.StateReader
+	if b.ChainConfig().Goat.Params(header.Time).FeeSplit {
+		statedb, _, err := b.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithHash(hash, false))
+		if statedb == nil || err != nil {
+			return nil, fmt.Errorf("block %d: %w", number, core.ErrGoatFeeRecordUnavailable)
+		}
+		state = statedb
+	}
+	return core.DeriveGoatFeeRecord(b.ChainConfig(), block, receipts, state)
+}
+
+// GoatTxArgs represents the arguments to simulate a goat tx.
+type GoatTxArgs struct {
+	Module goattypes.Module `json:"module"`
+	Action goattypes.Action `json:"action"`
+	Data   hexutil.Bytes    `json:"data"` // abi encoded input
+}
+
+// GoatBalanceChange is a balance change caused by a simulated goat tx.
+type GoatBalanceChange struct {
+	Address common.Address `json:"address"`
+	Prev    *hexutil.Big   `json:"prev"`
+	New     *hexutil.Big   `json:"new"`
+	Reason  string         `json:"reason"`
+}
+
+// GoatTxSimulation is the result of a simulated goat tx.
+type GoatTxSimulation struct {
+	ReturnValue    hexutil.Bytes        `json:"returnData"`
+	Logs           []*types.Log         `json:"logs"`
+	BalanceChanges []*GoatBalanceChange `json:"balanceChanges"`
+}
+
+// SimulateGoatTx executes the goat tx of the given module and action on the
+// state for the given block number. The tx is sent from the executor of the
+// module with its current nonce, and the deposit and claim amounts are minted
+// or transferred as they are in a block.
+//
+// Note, this function doesn't make any changes in the state/blockchain and is
+// useful to dry-run the goat txs before they are submitted by the relayer.
+func (api *BlockChainAPI) SimulateGoatTx(ctx context.Context, args GoatTxArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *StateOverride) (*GoatTxSimulation, error) {
+	config := api.b.ChainConfig()
+	if config.Goat == nil {
+		return nil, errNotGoatChain
+	}
+	inner, err := goattypes.DecodeTx(args.Module, args.Action, args.Data)
+	if err != nil {
+		return nil, &invalidParamsError{message: err.Error()}
+	}
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	statedb, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	blockCtx := core.NewEVMBlockContext(header, NewChainContext(ctx, api.b), nil)
+	rules := config.Rules(blockCtx.BlockNumber, blockCtx.Random != nil, blockCtx.Time)
+	precompiles := maps.Clone(vm.ActivePrecompiledContracts(rules))
+	if err := overrides.Apply(statedb, precompiles); err != nil {
+		return nil, err
+	}
+
+	tx := types.NewTx(types.NewGoatTx(args.Module, args.Action, statedb.GetNonce(inner.Sender()), inner))
+	msg, err := core.TransactionToMessage(tx, types.MakeSigner(config, header.Number, header.Time), header.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		changes []*GoatBalanceChange
+		tracer  = newTracer(false, header.Number.Uint64(), header.Hash(), tx.Hash(), 0)
+		hooks   = tracer.Hooks()
+	)
+	hooks.OnBalanceChange = func(addr common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+		changes = append(changes, &GoatBalanceChange{
+			Address: addr,
+			Prev:    (*hexutil.Big)(prev),
+			New:     (*hexutil.Big)(new),
+			Reason:  reason.String(),
+		})
+	}
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), state.NewHookedState(statedb, hooks), config, vm.Config{Tracer: hooks, NoBaseFee: true})
+	evm.SetPrecompiles(precompiles)
+
+	gp := new(core.GasPool).AddGas(header.GasLimit)
+	result, err := applyMessageWithEVM(ctx, evm, msg, api.b.RPCEVMTimeout(), gp)
+	if err := statedb.Error(); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &GoatTxSimulation{ReturnValue: result.Return(), Logs: tracer.Logs(), BalanceChanges: changes}, nil
+}
+
+// GetGoatTxProof returns the merkle proof of the goat tx at the index of the given
+// block under the goat tx root committed in the header extra. The proof could be
+// verified by goatproof.TxProof.Verify with the block header only.
+func (api *BlockChainAPI) GetGoatTxProof(ctx context.Context, blockHash common.Hash, index hexutil.Uint64) (*goatproof.TxProof, error) {
+	config := api.b.ChainConfig()
+	if config.Goat == nil {
+		return nil, errNotGoatChain
+	}
+	block, err := api.b.BlockByHash(ctx, blockHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	extra, err := goattypes.DecodeHeaderExtra(config.Goat.Params(block.Time()), block.Extra())
+	if err != nil {
+		return nil, err
+	}
+	if uint64(index) >= uint64(extra.TxCount) {
+		return nil, &invalidParamsError{message: fmt.Sprintf("goat tx index %d out of range, goat tx count %d", index, extra.TxCount)}
+	}
+	goatTxs := block.Transactions()[:extra.TxCount]
+
+	tr := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	if root := types.DeriveSha(goatTxs, tr); root != extra.TxRoot {
+		return nil, fmt.Errorf("goat tx root mismatch: have %x, want %x", root, extra.TxRoot)
+	}
+	var proof trienode.ProofList
+	if err := tr.Prove(rlp.AppendUint64(nil, uint64(index)), &proof); err != nil {
+		return nil, err
+	}
+	nodes := make([]hexutil.Bytes, len(proof))
+	for i, node := range proof {
+		nodes[i] = hexutil.Bytes(node)
+	}
+	return &goatproof.TxProof{
+		BlockHash:   blockHash,
+		BlockNumber: hexutil.Uint64(block.NumberU64()),
+		TxRoot:      extra.TxRoot,
+		Index:       index,
+		TxHash:      goatTxs[index].Hash(),
+		Proof:       nodes,
+	}, nil
+}
@@ -0,0 +1,352 @@
+package ethapi
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestGoatGetRequestsByBlock(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = core.DeveloperGoatGenesisBlock(30_000_000, &addr)
+		signer = types.LatestSigner(gspec.Config)
+		to     = common.HexToAddress("0x4a284d2835a3497e08b8b7fb30459a1c8229553d")
+	)
+	backend := newTestBackend(t, 2, gspec, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		if i == 1 {
+			return
+		}
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &to,
+			Gas:      21000,
+			GasPrice: big.NewInt(params.GWei),
+			Value:    big.NewInt(0),
+		}), signer, key)
+		b.AddTx(tx)
+	})
+	api := NewGoatAPI(backend)
+
+	// totalFee = 1e9 * 21000 and the foundation tax is 2%
+	tests := []struct {
+		number rpc.BlockNumber
+		reward *big.Int
+	}{
+		{1, big.NewInt(20580000000000)},
+		{2, new(big.Int)},
+	}
+	for _, tt := range tests {
+		result, err := api.GetRequestsByBlock(context.Background(), rpc.BlockNumberOrHashWithNumber(tt.number))
+		if err != nil {
+			t.Fatalf("block %d: %v", tt.number, err)
+		}
+		block := backend.chain.GetBlockByNumber(uint64(tt.number))
+		if result.BlockHash != block.Hash() || result.RequestsHash != *block.RequestsHash() {
+			t.Errorf("block %d: unexpected block or requests hash", tt.number)
+		}
+		if gas := result.Locking.Gas; len(gas) != 1 || gas[0].Height != uint64(tt.number) || gas[0].Amount.Cmp(tt.reward) != 0 {
+			t.Errorf("block %d: unexpected gas requests: %v", tt.number, gas)
+		}
+
+		// The typed json could be decoded by the client
+		enc, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var dec goattypes.BlockRequests
+		if err := json.Unmarshal(enc, &dec); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(&dec, result) {
+			t.Errorf("block %d: json round trip mismatch: have %+v, want %+v", tt.number, dec, result)
+		}
+	}
+
+	if _, err := api.GetRequestsByBlock(context.Background(), rpc.BlockNumberOrHashWithNumber(0)); err == nil {
+		t.Error("expected error for the genesis block")
+	}
+}
+
+func TestGoatGetRequestsByBlockNotGoat(t *testing.T) {
+	t.Parallel()
+
+	gspec := &core.Genesis{Config: params.MergedTestChainConfig, Alloc: types.GenesisAlloc{}}
+	backend := newTestBackend(t, 1, gspec, beacon.NewFaker(), nil)
+	if _, err := NewGoatAPI(backend).GetRequestsByBlock(context.Background(), rpc.BlockNumberOrHashWithNumber(1)); err != errNotGoatChain {
+		t.Errorf("unexpected error: have %v, want %v", err, errNotGoatChain)
+	}
+}
+
+func TestGoatGetBlockRewards(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = core.DeveloperGoatGenesisBlock(30_000_000, &addr)
+		signer = types.LatestSigner(gspec.Config)
+		to     = common.HexToAddress("0x4a284d2835a3497e08b8b7fb30459a1c8229553d")
+	)
+	backend := newTestBackend(t, 2, gspec, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		if i == 1 {
+			return
+		}
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &to,
+			Gas:      21000,
+			GasPrice: big.NewInt(params.GWei),
+			Value:    big.NewInt(0),
+		}), signer, key)
+		b.AddTx(tx)
+	})
+	api := NewGoatAPI(backend)
+
+	// totalFee = 1e9 * 21000 and the foundation tax is 2%
+	block := backend.chain.GetBlockByNumber(1)
+	burnt := new(big.Int).Mul(block.BaseFee(), big.NewInt(21000))
+	want := []*goattypes.BlockRewards{
+		goattypes.NewBlockRewards(block.Hash(), 1, &goattypes.FeeRecord{
+			Burnt:   burnt,
+			Tips:    new(big.Int).Sub(big.NewInt(21000000000000), burnt),
+			Tax:     big.NewInt(420000000000),
+			Revenue: big.NewInt(20580000000000),
+		}),
+		goattypes.NewBlockRewards(backend.chain.GetBlockByNumber(2).Hash(), 2, &goattypes.FeeRecord{
+			Burnt: new(big.Int), Tips: new(big.Int), Tax: new(big.Int), Revenue: new(big.Int),
+		}),
+	}
+	for _, w := range want {
+		have, err := api.GetBlockRewards(context.Background(), rpc.BlockNumberOrHashWithHash(w.BlockHash, false))
+		if err != nil {
+			t.Fatalf("block %d: %v", w.BlockNumber, err)
+		}
+		if !reflect.DeepEqual(have, w) {
+			t.Errorf("block %d: unexpected rewards: have %+v, want %+v", w.BlockNumber, have, w)
+		}
+	}
+	have, err := api.GetBlockRewardsByRange(context.Background(), 1, rpc.LatestBlockNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("unexpected range rewards: have %v, want %v", have, want)
+	}
+
+	// The rewards are re-derived if the fee record is not stored
+	if rawdb.ReadGoatFeeRecord(backend.db, want[0].BlockHash, 1) == nil {
+		t.Fatal("fee record is not stored")
+	}
+	rawdb.DeleteGoatFeeRecord(backend.db, want[0].BlockHash, 1)
+	if have, err := api.GetBlockRewards(context.Background(), rpc.BlockNumberOrHashWithNumber(1)); err != nil || !reflect.DeepEqual(have, want[0]) {
+		t.Errorf("unexpected derived rewards: have %+v, want %+v, err %v", have, want[0], err)
+	}
+
+	if _, err := api.GetBlockRewardsByRange(context.Background(), 2, 1); err == nil {
+		t.Error("expected error for the invalid range")
+	}
+	if _, err := api.GetBlockRewards(context.Background(), rpc.BlockNumberOrHashWithNumber(0)); err == nil {
+		t.Error("expected error for the genesis block")
+	}
+}
+
+func TestGoatSimulateGoatTx(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gspec   = core.DeveloperGoatGenesisBlock(30_000_000, nil)
+		backend = newTestBackend(t, 1, gspec, beacon.NewFaker(), nil)
+		api     = NewBlockChainAPI(backend)
+		target  = common.HexToAddress("0xdeadbeef")
+		amount  = big.NewInt(params.Ether)
+		deposit = &goattypes.DepositTx{Txid: common.HexToHash("0x01"), TxOut: 1, Target: target, Amount: amount}
+	)
+	args := GoatTxArgs{Module: goattypes.BirdgeModule, Action: goattypes.BridgeDepoitAction, Data: deposit.Encode()}
+	result, err := api.SimulateGoatTx(context.Background(), args, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The deposit amount is shared by the target and the foundation tax
+	total := new(big.Int)
+	for _, change := range result.BalanceChanges {
+		switch change.Address {
+		case target:
+			if change.Reason != tracing.BalanceGoatDepoist.String() {
+				t.Errorf("unexpected balance change reason of the target: %s", change.Reason)
+			}
+		case goattypes.GoatFoundationContract:
+			if change.Reason != tracing.BalanceGoatDepositTax.String() {
+				t.Errorf("unexpected balance change reason of the foundation: %s", change.Reason)
+			}
+		default:
+			t.Errorf("unexpected balance change of %s", change.Address)
+		}
+		total.Add(total, new(big.Int).Sub(change.New.ToInt(), change.Prev.ToInt()))
+	}
+	if total.Cmp(amount) != 0 {
+		t.Errorf("unexpected deposited amount: have %s, want %s", total, amount)
+	}
+	var found bool
+	for _, l := range result.Logs {
+		if l.Address == goattypes.BridgeContract && len(l.Topics) > 0 && l.Topics[0] == goattypes.DepositEventTopic {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("deposit event not found in logs: %v", result.Logs)
+	}
+
+	// The simulation doesn't change the state
+	statedb, _, err := backend.StateAndHeaderByNumber(context.Background(), rpc.LatestBlockNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance := statedb.GetBalance(target); !balance.IsZero() {
+		t.Errorf("unexpected target balance: %s", balance)
+	}
+
+	// Unknown goat txs are rejected
+	args.Action = 100
+	if _, err := api.SimulateGoatTx(context.Background(), args, nil, nil); err == nil {
+		t.Error("expected error for the unknown goat tx")
+	}
+}
+
+func TestGoatGetGoatTxProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gspec  = core.DeveloperGoatGenesisBlock(30_000_000, nil)
+		target = common.HexToAddress("0xdeadbeef")
+		amount = big.NewInt(params.Ether)
+		count  = 20
+	)
+	backend := newTestBackend(t, 1, gspec, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		for j := 0; j < count; j++ {
+			b.AddDeposit(common.BigToHash(big.NewInt(int64(j+1))), uint32(j), target, amount)
+		}
+	})
+	var (
+		api    = NewBlockChainAPI(backend)
+		block  = backend.chain.GetBlockByNumber(1)
+		goat   = gspec.Config.Goat.Params(block.Time())
+		extra  = block.Extra()
+		ctx    = context.Background()
+		txHash = block.Transactions()[1].Hash()
+	)
+	for i := 0; i < count; i++ {
+		proof, err := api.GetGoatTxProof(ctx, block.Hash(), hexutil.Uint64(i))
+		if err != nil {
+			t.Fatalf("tx %d: %v", i, err)
+		}
+		tx, err := proof.Verify(goat, extra)
+		if err != nil {
+			t.Fatalf("tx %d: failed to verify the proof: %v", i, err)
+		}
+		deposit, ok := tx.Inner().(*goattypes.DepositTx)
+		if !ok || deposit.TxOut != uint32(i) || deposit.Target != target || tx.Nonce != uint64(i) {
+			t.Errorf("tx %d: unexpected proven tx %+v", i, tx)
+		}
+	}
+
+	// The tampered proofs are rejected
+	proof, err := api.GetGoatTxProof(ctx, block.Hash(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof.Index = 2
+	if _, err := proof.Verify(goat, extra); err == nil {
+		t.Error("expected error for the wrong index")
+	}
+	proof.Index, proof.TxHash = 1, common.Hash{}
+	if _, err := proof.Verify(goat, extra); err == nil {
+		t.Error("expected error for the wrong tx hash")
+	}
+	proof.TxHash = txHash
+	proof.Proof = proof.Proof[:len(proof.Proof)-1]
+	if _, err := proof.Verify(goat, extra); err == nil {
+		t.Error("expected error for the incomplete proof")
+	}
+
+	if _, err := api.GetGoatTxProof(ctx, block.Hash(), hexutil.Uint64(count)); err == nil {
+		t.Error("expected error for the out of range index")
+	}
+}
+
+func TestGoatRPCTransaction(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gspec     = core.DeveloperGoatGenesisBlock(30_000_000, nil)
+		target    = common.HexToAddress("0xdeadbeef")
+		recipient = common.HexToAddress("0x5b38da6a701c568545dcfcb03fcb875f56beddc4")
+		amount    = big.NewInt(params.Ether)
+		reward    = big.NewInt(params.GWei)
+		txs       []*types.Transaction
+	)
+	backend := newTestBackend(t, 1, gspec, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		txs = append(txs, b.AddDeposit(common.HexToHash("0x01"), 2, target, amount))
+		txs = append(txs, b.AddDistributeReward(0, recipient, new(big.Int), reward))
+	})
+	api := NewTransactionAPI(backend, new(AddrLocker))
+
+	tests := []struct {
+		want string
+	}{
+		{
+			want: `{"moduleName":"bridge","actionName":"deposit","payload":{"txid":"0x0000000000000000000000000000000000000000000000000000000000000001","txout":"0x2","target":"0x00000000000000000000000000000000deadbeef","amount":"0xde0b6b3a7640000"},"deposit":{"address":"0x00000000000000000000000000000000deadbeef","amount":"0xde0b6b3a7640000"}}`,
+		},
+		{
+			want: `{"moduleName":"locking","actionName":"distributeReward","payload":{"id":"0x0","recipient":"0x5b38da6a701c568545dcfcb03fcb875f56beddc4","goat":"0x0","gasReward":"0x3b9aca00"},"claim":{"address":"0x5b38da6a701c568545dcfcb03fcb875f56beddc4","amount":"0x3b9aca00"}}`,
+		},
+	}
+	for i, tt := range tests {
+		rpcTx, err := api.GetTransactionByHash(context.Background(), txs[i].Hash())
+		if err != nil {
+			t.Fatalf("tx %d: %v", i, err)
+		}
+		if have, _ := json.Marshal(rpcTx.Goat); string(have) != tt.want {
+			t.Errorf("tx %d: goat tx info mismatch:\nhave %s\nwant %s", i, have, tt.want)
+		}
+
+		// The RPC transaction is decoded to the same goat tx
+		enc, err := json.Marshal(rpcTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tx types.Transaction
+		if err := json.Unmarshal(enc, &tx); err != nil {
+			t.Fatalf("tx %d: failed to decode the rpc transaction: %v", i, err)
+		}
+		if tx.Hash() != txs[i].Hash() {
+			t.Errorf("tx %d: decoded tx hash mismatch: have %x, want %x", i, tx.Hash(), txs[i].Hash())
+		}
+
+		receipt, err := api.GetTransactionReceipt(context.Background(), txs[i].Hash())
+		if err != nil {
+			t.Fatalf("tx %d: %v", i, err)
+		}
+		if have, _ := json.Marshal(receipt["goat"]); string(have) != tt.want {
+			t.Errorf("tx %d: receipt goat tx info mismatch:\nhave %s\nwant %s", i, have, tt.want)
+		}
+	}
+}
@@ -121,6 +121,9 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 		}, {
 			Namespace: "personal",
 			Service:   NewPersonalAccountAPI(apiBackend, nonceLock),
+		}, {
+			Namespace: "goat",
+			Service:   NewGoatAPI(apiBackend),
 		},
 	}
 }
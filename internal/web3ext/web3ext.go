@@ -393,6 +393,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceGoatGasFee',
+			call: 'debug_traceGoatGasFee',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
 		new web3._extend.Method({
 			name: 'traceTransaction',
 			call: 'debug_traceTransaction',
@@ -469,6 +475,12 @@ web3._extend({
 			call: 'debug_getTrieFlushInterval',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'goatPayloadBuilds',
+			call: 'debug_goatPayloadBuilds',
+			params: 1,
+			inputFormatter: [null]
+		}),
 	],
 	properties: []
 });
@@ -73,6 +73,8 @@ type Miner struct {
 	chain       *core.BlockChain
 	pending     *pending
 	pendingMu   sync.Mutex // Lock protects the pending block
+
+	goatBuilds goatBuildHistory // Recent payload build attempts of the goat chain
 }
 
 // New creates a new miner with provided config.
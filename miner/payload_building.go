@@ -45,7 +45,8 @@ type BuildPayloadArgs struct {
 	BeaconRoot   *common.Hash          // The provided beaconRoot (Cancun)
 	Version      engine.PayloadVersion // Versioning byte for payload id calculation.
 
-	GoatTxs types.Transactions
+	GoatTxs  types.Transactions
+	GoatWork *GoatWork // The goat txs applied by PrepareGoatWork, nil to apply them in the building
 }
 
 // Id computes an 8-byte identifier by hashing the components of the payload arguments.
@@ -223,7 +224,19 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 		noTxs:       true,
 		txs:         args.GoatTxs,
 	}
+	// Apply the goat txs once, the empty and full payloads are built on the
+	// copies of the same work.
+	emptyParams.goatWork = args.GoatWork
+	if emptyParams.goatWork == nil && len(args.GoatTxs) != 0 {
+		work, err := miner.PrepareGoatWork(args, witness)
+		if err != nil {
+			return nil, err
+		}
+		emptyParams.goatWork = work
+	}
+	start := time.Now()
 	empty := miner.generateWork(emptyParams, witness)
+	miner.recordGoatBuild(args.Id(), emptyParams, empty, start)
 	if empty.err != nil {
 		return nil, empty.err
 	}
@@ -253,6 +266,7 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 			beaconRoot:  args.BeaconRoot,
 			noTxs:       false,
 			txs:         args.GoatTxs,
+			goatWork:    emptyParams.goatWork,
 		}
 
 		for {
@@ -260,6 +274,7 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 			case <-timer.C:
 				start := time.Now()
 				r := miner.generateWork(fullParams, witness)
+				miner.recordGoatBuild(payload.id, fullParams, r, start)
 				if r.err == nil {
 					payload.update(r, time.Since(start))
 				} else {
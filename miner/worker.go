@@ -34,7 +34,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
-	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
 )
 
@@ -79,6 +78,9 @@ type newPayloadResult struct {
 	receipts []*types.Receipt       // Receipts collected during construction
 	requests [][]byte               // Consensus layer requests collected during block construction
 	witness  *stateless.Witness     // Witness is an optional stateless proof
+
+	goatTxs    []*GoatTxResult // Execution results of the goat txs
+	mempoolTxs int             // Number of the txs filled from the mempool
 }
 
 // generateParams wraps various settings for generating sealing task.
@@ -93,14 +95,15 @@ type generateParams struct {
 	noTxs       bool              // Flag whether an empty block without any transaction is expected
 
 	// goat txs from cosmos
-	txs types.Transactions
+	txs      types.Transactions
+	goatWork *GoatWork // the work with the goat txs applied, nil to apply them in place
 }
 
 // generateWork generates a sealing block based on the given parameters.
 func (miner *Miner) generateWork(params *generateParams, witness bool) *newPayloadResult {
-	work, err := miner.prepareWork(params, witness)
+	work, goatTxs, err := miner.prepareGoatWork(params, witness)
 	if err != nil {
-		return &newPayloadResult{err: err}
+		return &newPayloadResult{err: err, goatTxs: goatTxs}
 	}
 	if !params.noTxs {
 		interrupt := new(atomic.Int32)
@@ -138,21 +141,28 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 			burntFees.Add(burntFees, blobUsed.Mul(blobUsed, blobBaseFee))
 		}
 
-		gasFees.Add(gasFees, burntFees)
+		gasTips := new(big.Int)
 		for i, tx := range work.txs {
 			gasUsed := work.receipts[i].GasUsed
 			if gasUsed == 0 { // It's the goat tx
 				continue
 			}
 			minerFee, _ := tx.EffectiveGasTip(work.header.BaseFee)
-			gasFees.Add(gasFees, new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), minerFee))
+			gasTips.Add(gasTips, new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), minerFee))
 		}
-		gasRevenue := core.ProcessGoatGasFee(work.state, gasFees)
-		goatRequests, err := core.ProcessGoatRequests(work.header.Number.Uint64(), gasRevenue, allLogs)
+		gasFees.Add(burntFees, gasTips)
+		goatFees := core.ProcessGoatGasFee(miner.chainConfig.Goat.Params(work.header.Time), work.state, burntFees, gasTips)
+		goatRequests, err := core.ProcessGoatRequests(work.header.Number.Uint64(), goatFees.Revenue, allLogs)
 		if err != nil {
-			return &newPayloadResult{err: err}
+			return &newPayloadResult{err: err, goatTxs: goatTxs}
 		}
 		requests = goatRequests
+
+		extra, err := core.NewGoatHeaderExtra(miner.chainConfig.Goat.Params(work.header.Time), params.txs, requests, work.state)
+		if err != nil {
+			return &newPayloadResult{err: err, goatTxs: goatTxs}
+		}
+		work.header.Extra = extra
 	}
 	if miner.chainConfig.Goat == nil && miner.chainConfig.IsPrague(work.header.Number, work.header.Time) {
 		// EIP-6110 deposits
@@ -193,6 +203,9 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 		receipts: work.receipts,
 		requests: requests,
 		witness:  work.witness,
+
+		goatTxs:    goatTxs,
+		mempoolTxs: len(work.txs) - len(goatTxs),
 	}
 }
 
@@ -231,11 +244,11 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 	}
 
 	if miner.chainConfig.Goat != nil {
-		// Set the extra field.
-		header.Extra = make([]byte, 0, params.GoatHeaderExtraLengthV0)
-		header.Extra = append(header.Extra, uint8(len(genParams.txs)))
-		header.Extra = append(header.Extra,
-			types.DeriveSha(genParams.txs, trie.NewStackTrie(nil)).Bytes()...)
+		goat := miner.chainConfig.Goat.Params(timestamp)
+		if len(genParams.txs) > goat.TxLimitPerBlock {
+			return nil, fmt.Errorf("too many goat txs: have %d, max %d", len(genParams.txs), goat.TxLimitPerBlock)
+		}
+		// The extra field is set once the goat requests are generated.
 	} else {
 		// Set the extra field.
 		if len(miner.config.ExtraData) != 0 {
@@ -282,6 +295,9 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 		log.Error("Failed to create sealing context", "err", err)
 		return nil, err
 	}
+	if err := core.CheckGoatBeaconRoot(miner.chainConfig, header.Time, header.ParentBeaconRoot); err != nil {
+		return nil, err
+	}
 	if header.ParentBeaconRoot != nil {
 		context := core.NewEVMBlockContext(header, miner.chain, nil)
 		vmenv := vm.NewEVM(context, vm.TxContext{}, env.state, miner.chainConfig, vm.Config{})
@@ -293,18 +309,6 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 		core.ProcessParentBlockHash(header.ParentHash, vmenv, env.state)
 	}
 
-	// add goat txs
-	if env.gasPool == nil {
-		env.gasPool = new(core.GasPool).AddGas(header.GasLimit)
-	}
-	for _, tx := range genParams.txs {
-		env.state.SetTxContext(tx.Hash(), env.tcount)
-		err = miner.commitTransaction(env, tx)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	return env, nil
 }
 
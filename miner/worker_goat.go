@@ -0,0 +1,283 @@
+package miner
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// GoatBuildHistoryLimit is the number of the recent payload build attempts
+// retained for debugging.
+const GoatBuildHistoryLimit = 64
+
+var (
+	goatBuildTimer      = metrics.NewRegisteredTimer("miner/goat/build", nil)
+	goatBuildFailMeter  = metrics.NewRegisteredMeter("miner/goat/build/fail", nil)
+	goatTxFailMeter     = metrics.NewRegisteredMeter("miner/goat/txs/fail", nil)
+	goatTxsHistogram    = metrics.NewRegisteredHistogram("miner/goat/txs", nil, metrics.NewExpDecaySample(1028, 0.015))
+	mempoolTxsHistogram = metrics.NewRegisteredHistogram("miner/goat/mempooltxs", nil, metrics.NewExpDecaySample(1028, 0.015))
+)
+
+// GoatTxResult is the execution result of a consensus supplied goat tx in a
+// payload build attempt.
+type GoatTxResult struct {
+	Hash         common.Hash      `json:"hash"`
+	Module       goattypes.Module `json:"module"`
+	Action       goattypes.Action `json:"action"`
+	GasUsed      hexutil.Uint64   `json:"gasUsed"` // gas used by the evm, it's refunded in the receipt
+	ReturnData   hexutil.Bytes    `json:"returnData"`
+	RevertReason string           `json:"revertReason,omitempty"`
+	Error        string           `json:"error,omitempty"`
+}
+
+// GoatBuildAttempt is a payload build attempt of the goat chain.
+type GoatBuildAttempt struct {
+	PayloadID  engine.PayloadID `json:"payloadId"`
+	ParentHash common.Hash      `json:"parentHash"`
+	Timestamp  hexutil.Uint64   `json:"timestamp"`
+	Empty      bool             `json:"empty"` // whether the mempool txs are excluded
+	Time       time.Time        `json:"time"`
+	Elapsed    string           `json:"elapsed"`
+
+	GoatTxs    []*GoatTxResult `json:"goatTxs"`
+	MempoolTxs int             `json:"mempoolTxs"`
+
+	BlockHash *common.Hash `json:"blockHash,omitempty"` // nil if the build failed
+	Error     string       `json:"error,omitempty"`
+}
+
+// goatBuildHistory is a ring buffer of the recent payload build attempts.
+type goatBuildHistory struct {
+	attempts []*GoatBuildAttempt
+	next     int
+	mu       sync.Mutex
+}
+
+func (h *goatBuildHistory) add(attempt *GoatBuildAttempt) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.attempts) < GoatBuildHistoryLimit {
+		h.attempts = append(h.attempts, attempt)
+		return
+	}
+	h.attempts[h.next] = attempt
+	h.next = (h.next + 1) % GoatBuildHistoryLimit
+}
+
+// last returns the last n attempts ordered by the build time.
+func (h *goatBuildHistory) last(n int) []*GoatBuildAttempt {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n = max(min(n, len(h.attempts)), 0)
+	res := make([]*GoatBuildAttempt, 0, n)
+	for i := len(h.attempts) - n; i < len(h.attempts); i++ {
+		res = append(res, h.attempts[(h.next+i)%len(h.attempts)])
+	}
+	return res
+}
+
+// GoatPayloadBuilds returns the last n payload build attempts of the goat chain.
+func (miner *Miner) GoatPayloadBuilds(n int) []*GoatBuildAttempt {
+	return miner.goatBuilds.last(n)
+}
+
+// recordGoatBuild reports the payload build attempt to the metrics and the
+// build history.
+func (miner *Miner) recordGoatBuild(id engine.PayloadID, params *generateParams, result *newPayloadResult, start time.Time) {
+	if miner.chainConfig.Goat == nil {
+		return
+	}
+	elapsed := time.Since(start)
+	attempt := &GoatBuildAttempt{
+		PayloadID:  id,
+		ParentHash: params.parentHash,
+		Timestamp:  hexutil.Uint64(params.timestamp),
+		Empty:      params.noTxs,
+		Time:       start,
+		Elapsed:    common.PrettyDuration(elapsed).String(),
+		GoatTxs:    result.goatTxs,
+		MempoolTxs: result.mempoolTxs,
+	}
+	goatBuildTimer.Update(elapsed)
+	goatTxsHistogram.Update(int64(len(result.goatTxs)))
+	if result.err != nil {
+		goatBuildFailMeter.Mark(1)
+		attempt.Error = result.err.Error()
+	} else {
+		hash := result.block.Hash()
+		attempt.BlockHash = &hash
+		mempoolTxsHistogram.Update(int64(result.mempoolTxs))
+	}
+	miner.goatBuilds.add(attempt)
+}
+
+// commitGoatTxs applies the consensus supplied goat txs at the head of the
+// block. The execution results are returned even if a goat tx failed, which
+// aborts the whole payload.
+func (miner *Miner) commitGoatTxs(env *environment, txs types.Transactions) ([]*GoatTxResult, error) {
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+	results := make([]*GoatTxResult, 0, len(txs))
+	for _, tx := range txs {
+		result := &GoatTxResult{Hash: tx.Hash()}
+		if goatTx := tx.AsGoatTx(); goatTx != nil {
+			result.Module, result.Action = goatTx.Module, goatTx.Action
+		}
+		results = append(results, result)
+
+		hooks := &tracing.Hooks{
+			OnExit: func(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+				if depth != 0 {
+					return
+				}
+				result.GasUsed, result.ReturnData = hexutil.Uint64(gasUsed), common.CopyBytes(output)
+				if reverted {
+					if reason, errUnpack := abi.UnpackRevert(output); errUnpack == nil {
+						result.RevertReason = reason
+					}
+				}
+			},
+		}
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		receipt, err := core.ApplyTransaction(miner.chainConfig, miner.chain, &env.coinbase, env.gasPool, env.state, env.header, tx, &env.header.GasUsed, vm.Config{Tracer: hooks})
+		if err != nil {
+			goatTxFailMeter.Mark(1)
+			result.Error = err.Error()
+			return results, err
+		}
+		env.txs = append(env.txs, tx)
+		env.receipts = append(env.receipts, receipt)
+		env.tcount++
+	}
+	return results, nil
+}
+
+// GoatTxError is a consensus supplied goat tx rejected by the payload building.
+type GoatTxError struct {
+	Index int // index of the goat tx in the payload attributes
+	Err   error
+}
+
+func (e *GoatTxError) Error() string {
+	return fmt.Sprintf("goat tx %d: %v", e.Index, e.Err)
+}
+
+func (e *GoatTxError) Unwrap() error {
+	return e.Err
+}
+
+// GoatWork is a sealing environment with the consensus supplied goat txs applied.
+// The payloads of the same attributes are built on its copies, so that the goat
+// txs are executed once for all the build attempts.
+type GoatWork struct {
+	env     *environment
+	results []*GoatTxResult
+}
+
+// PrepareGoatWork validates and applies the goat txs of the payload arguments
+// before the payload building starts. The failing goat tx is reported by a
+// GoatTxError, otherwise the work is handed to BuildPayload by the GoatWork
+// field of the arguments.
+func (miner *Miner) PrepareGoatWork(args *BuildPayloadArgs, witness bool) (*GoatWork, error) {
+	params := &generateParams{
+		timestamp:   args.Timestamp,
+		forceTime:   true,
+		parentHash:  args.Parent,
+		coinbase:    args.FeeRecipient,
+		random:      args.Random,
+		withdrawals: args.Withdrawals,
+		beaconRoot:  args.BeaconRoot,
+		noTxs:       true,
+		txs:         args.GoatTxs,
+	}
+	start := time.Now()
+	work, results, err := miner.prepareGoatWork(params, witness)
+	if err != nil {
+		miner.recordGoatBuild(args.Id(), params, &newPayloadResult{err: err, goatTxs: results}, start)
+		return nil, err
+	}
+	return &GoatWork{env: work, results: results}, nil
+}
+
+// prepareGoatWork prepares the sealing environment with the goat txs applied. It
+// copies the goat work of the params if there is one, otherwise the goat txs are
+// validated and applied on a new environment. The failing goat tx is reported by
+// a GoatTxError.
+func (miner *Miner) prepareGoatWork(params *generateParams, witness bool) (*environment, []*GoatTxResult, error) {
+	if params.goatWork != nil {
+		return params.goatWork.env.copy(), params.goatWork.results, nil
+	}
+	work, err := miner.prepareWork(params, witness)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateGoatTxs(work, params.txs); err != nil {
+		return nil, nil, err
+	}
+	results, err := miner.commitGoatTxs(work, params.txs)
+	if err != nil {
+		return nil, results, &GoatTxError{Index: len(results) - 1, Err: err}
+	}
+	return work, results, nil
+}
+
+// validateGoatTxs checks that the goat txs are sent with the executor nonces in
+// sequence, before they're applied.
+func validateGoatTxs(env *environment, txs types.Transactions) error {
+	nonces := make(map[common.Address]uint64)
+	for i, tx := range txs {
+		goatTx := tx.AsGoatTx()
+		if goatTx == nil {
+			return &GoatTxError{Index: i, Err: fmt.Errorf("not a goat tx (type %d)", tx.Type())}
+		}
+		sender := goatTx.Sender()
+		nonce, ok := nonces[sender]
+		if !ok {
+			nonce = env.state.GetNonce(sender)
+		}
+		switch {
+		case goatTx.Nonce > nonce:
+			return &GoatTxError{Index: i, Err: fmt.Errorf("%w: executor %v, tx %d, want %d", core.ErrNonceTooHigh, sender, goatTx.Nonce, nonce)}
+		case goatTx.Nonce < nonce:
+			return &GoatTxError{Index: i, Err: fmt.Errorf("%w: executor %v, tx %d, want %d", core.ErrNonceTooLow, sender, goatTx.Nonce, nonce)}
+		}
+		nonces[sender] = nonce + 1
+	}
+	return nil
+}
+
+// copy returns a deep copy of the environment to build a payload on.
+func (env *environment) copy() *environment {
+	cpy := &environment{
+		signer:   env.signer,
+		state:    env.state.Copy(),
+		tcount:   env.tcount,
+		coinbase: env.coinbase,
+		header:   types.CopyHeader(env.header),
+		txs:      slices.Clone(env.txs),
+		receipts: slices.Clone(env.receipts),
+		sidecars: slices.Clone(env.sidecars),
+		blobs:    env.blobs,
+	}
+	if env.gasPool != nil {
+		gasPool := *env.gasPool
+		cpy.gasPool = &gasPool
+	}
+	cpy.witness = cpy.state.Witness()
+	return cpy
+}
@@ -0,0 +1,27 @@
+package miner
+
+import (
+	"testing"
+)
+
+func TestGoatBuildHistory(t *testing.T) {
+	var history goatBuildHistory
+	for i := 0; i < GoatBuildHistoryLimit+10; i++ {
+		history.add(&GoatBuildAttempt{MempoolTxs: i})
+	}
+	attempts := history.last(3)
+	if len(attempts) != 3 {
+		t.Fatalf("unexpected attempt count: have %d, want 3", len(attempts))
+	}
+	for i, attempt := range attempts {
+		if want := GoatBuildHistoryLimit + 7 + i; attempt.MempoolTxs != want {
+			t.Errorf("attempt %d: unexpected order: have %d, want %d", i, attempt.MempoolTxs, want)
+		}
+	}
+	if attempts := history.last(GoatBuildHistoryLimit * 2); len(attempts) != GoatBuildHistoryLimit || attempts[0].MempoolTxs != 10 {
+		t.Errorf("unexpected retained attempts: have %d from %d", len(attempts), attempts[0].MempoolTxs)
+	}
+	if attempts := history.last(-1); len(attempts) != 0 {
+		t.Errorf("unexpected attempts for negative count: %d", len(attempts))
+	}
+}
@@ -428,6 +428,17 @@ func (c *ChainConfig) Description() string {
 	if c.VerkleTime != nil {
 		banner += fmt.Sprintf(" - Verkle:                      @%-10v\n", *c.VerkleTime)
 	}
+	if c.Goat != nil && len(c.Goat.Forks) != 0 {
+		banner += "\n"
+		banner += "Goat forks (timestamp based):\n"
+		for i, fork := range c.Goat.Forks {
+			name := fork.Name
+			if name == "" {
+				name = fmt.Sprintf("Goat fork %d", i)
+			}
+			banner += fmt.Sprintf(" - %-28s @%-10v\n", name+":", fork.Time)
+		}
+	}
 	return banner
 }
 
@@ -626,6 +637,9 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	if c.Goat != nil {
+		return c.Goat.CheckConfigForkOrder()
+	}
 	return nil
 }
 
@@ -697,6 +711,11 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if isForkTimestampIncompatible(c.VerkleTime, newcfg.VerkleTime, headTimestamp) {
 		return newTimestampCompatError("Verkle fork timestamp", c.VerkleTime, newcfg.VerkleTime)
 	}
+	if c.Goat != nil && newcfg.Goat != nil {
+		if err := c.Goat.checkCompatible(newcfg.Goat, headTimestamp); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1,19 +1,154 @@
 package params
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
-type GoatConfig struct{}
-
 const (
-	GoatHeaderExtraLengthV0 = 33
+	GoatHeaderExtraLengthV0 = 33 // [goat tx count][goat tx root]
+	GoatHeaderExtraLengthV1 = 46 // [version][goat tx count][goat tx root][goat requests count][btc height]
 	GoatTxLimitPerBlock     = 128
 	GoatTxGasLimit          = 30_000_000 // the goat tx gas limit, it's the same with eth system tx
+	GoatFoundationTaxV0     = 200        // the foundation share of the gas fee in basis points(2%)
+
+	GoatMaxBasisPoints       = 10_000
+	GoatMaxFeeShares         = 16 // Max number of the gas fee recipients read from the foundation contract
+	GoatMaxHeaderExtraLength = 64 // Upper bound of the header extra length a goat fork can define
+
+	GoatBridgeIndexBlocks   uint64 = 16 // Number of blocks a single bridge index section covers
+	GoatBridgeIndexConfirms uint64 = 2  // Number of confirmation blocks before a bridge index section is processed
+
+	GoatLockingIndexBlocks   uint64 = 16 // Number of blocks a single locking index section covers
+	GoatLockingIndexConfirms uint64 = 2  // Number of confirmation blocks before a locking index section is processed
 )
 
+// GoatParams are the goat consensus parameters which can be changed by a goat fork.
+type GoatParams struct {
+	TxLimitPerBlock    int    `json:"txLimitPerBlock"`              // Max number of goat txs in a block
+	TxGasLimit         uint64 `json:"txGasLimit"`                   // Gas limit to execute a goat tx
+	HeaderExtraLength  int    `json:"headerExtraLength"`            // Length of the header extra which commits the goat txs
+	HeaderExtraVersion uint8  `json:"headerExtraVersion,omitempty"` // Layout version of the header extra
+	FoundationTax      uint64 `json:"foundationTax"`                // Foundation share of the gas fee in basis points
+	BeaconRoot         bool   `json:"beaconRoot"`                   // Whether the EIP-4788 beacon root provided by the consensus layer is processed
+	FeeSplit           bool   `json:"feeSplit,omitempty"`           // Whether the gas fee shares are read from the foundation contract instead of the foundation tax
+	RelayerTxs         bool   `json:"relayerTxs,omitempty"`         // Whether the goat txs of the relayer module are accepted
+}
+
+// GoatParamsV0 are the consensus parameters used before the first goat fork.
+var GoatParamsV0 = GoatParams{
+	TxLimitPerBlock:   GoatTxLimitPerBlock,
+	TxGasLimit:        GoatTxGasLimit,
+	HeaderExtraLength: GoatHeaderExtraLengthV0,
+	FoundationTax:     GoatFoundationTaxV0,
+}
+
+func (p *GoatParams) validate() error {
+	// the goat tx count is stored in one byte of the header extra
+	if p.TxLimitPerBlock <= 0 || p.TxLimitPerBlock > 255 {
+		return fmt.Errorf("invalid goat tx limit per block %d", p.TxLimitPerBlock)
+	}
+	if p.TxGasLimit == 0 {
+		return errors.New("goat tx gas limit should not be 0")
+	}
+	var minExtraLength int
+	switch p.HeaderExtraVersion {
+	case 0:
+		minExtraLength = GoatHeaderExtraLengthV0
+	case 1:
+		minExtraLength = GoatHeaderExtraLengthV1
+	default:
+		return fmt.Errorf("unsupported goat header extra version %d", p.HeaderExtraVersion)
+	}
+	if p.HeaderExtraLength < minExtraLength || p.HeaderExtraLength > GoatMaxHeaderExtraLength {
+		return fmt.Errorf("invalid goat header extra length %d", p.HeaderExtraLength)
+	}
+	if p.FoundationTax > GoatMaxBasisPoints {
+		return fmt.Errorf("goat foundation tax %d exceeds %d basis points", p.FoundationTax, GoatMaxBasisPoints)
+	}
+	return nil
+}
+
+// GoatFork schedules a new set of goat consensus parameters activated at the given timestamp.
+type GoatFork struct {
+	Name string `json:"name,omitempty"`
+	Time uint64 `json:"time"`
+
+	GoatParams
+}
+
+// GoatConfig is the consensus engine config for the goat network.
+type GoatConfig struct {
+	Forks []*GoatFork `json:"forks,omitempty"` // Goat forks sorted by the activation time
+}
+
+// Params returns the goat consensus parameters active at the given timestamp.
+func (c *GoatConfig) Params(time uint64) GoatParams {
+	if c != nil {
+		for i := len(c.Forks) - 1; i >= 0; i-- {
+			if c.Forks[i].Time <= time {
+				return c.Forks[i].GoatParams
+			}
+		}
+	}
+	return GoatParamsV0
+}
+
+// CheckConfigForkOrder checks the goat forks are sorted by the activation time
+// and every fork has valid consensus parameters.
+func (c *GoatConfig) CheckConfigForkOrder() error {
+	for i, fork := range c.Forks {
+		if fork == nil {
+			return fmt.Errorf("goat fork %d is empty", i)
+		}
+		if i > 0 && c.Forks[i-1].Time >= fork.Time {
+			return fmt.Errorf("unsupported goat fork ordering: fork %d enabled at timestamp %d, but fork %d enabled at timestamp %d",
+				i-1, c.Forks[i-1].Time, i, fork.Time)
+		}
+		if err := fork.validate(); err != nil {
+			return fmt.Errorf("goat fork %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// checkCompatible checks that none of the goat forks active at the head are changed.
+func (c *GoatConfig) checkCompatible(newcfg *GoatConfig, headTimestamp uint64) *ConfigCompatError {
+	for i := 0; i < len(c.Forks) || i < len(newcfg.Forks); i++ {
+		var stored, updated *GoatFork
+		if i < len(c.Forks) {
+			stored = c.Forks[i]
+		}
+		if i < len(newcfg.Forks) {
+			updated = newcfg.Forks[i]
+		}
+		storedActive := stored != nil && stored.Time <= headTimestamp
+		updatedActive := updated != nil && updated.Time <= headTimestamp
+		if !storedActive && !updatedActive {
+			break
+		}
+		if stored == nil || updated == nil || *stored != *updated {
+			var storedtime, newtime *uint64
+			if stored != nil {
+				storedtime = &stored.Time
+			}
+			if updated != nil {
+				newtime = &updated.Time
+			}
+			return newTimestampCompatError(fmt.Sprintf("Goat fork %d", i), storedtime, newtime)
+		}
+	}
+	return nil
+}
+
+// GoatTestnetGenesisHash is the genesis hash to enforce the testnet config on.
+var GoatTestnetGenesisHash = common.HexToHash("0x04a0d7e5aa1612f3f3bfe6b2beef2b4e5df798a71152876f397079490720de34")
+
+// V5GoatTestnetBootnodes are the enode URLs of the P2P bootstrap nodes running on
+// the Goat test network.
 var V5GoatTestnetBootnodes = []string{
 	"enode://fe03b4a568714cad3abeab6c2e5d0706df3bac321eeecefe9b0b3d3530a4f72793c84e50f039686a73d9ce74f75e044b5fb7ae6f12188e7d06c9dfaea9dc8033@34.213.22.74:30303",
 	"enode://87d9de2207172645b9edb3b81bc635a086a8e4e2fbbe5beb33c94945725ce1721c7aa3515f111865a928f73e88d28d37928e49e0de1cd11c4227fef0ea225cbd@35.92.200.59:30303",
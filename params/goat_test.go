@@ -0,0 +1,87 @@
+package params
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoatConfigParams(t *testing.T) {
+	v1 := GoatParams{TxLimitPerBlock: 64, TxGasLimit: 20_000_000, HeaderExtraLength: 40, FoundationTax: 500}
+	v2 := GoatParams{TxLimitPerBlock: 255, TxGasLimit: 30_000_000, HeaderExtraLength: 33, FoundationTax: 0}
+	config := &GoatConfig{Forks: []*GoatFork{{Time: 100, GoatParams: v1}, {Time: 200, GoatParams: v2}}}
+
+	tests := []struct {
+		config *GoatConfig
+		time   uint64
+		want   GoatParams
+	}{
+		{nil, 0, GoatParamsV0},
+		{&GoatConfig{}, 1000, GoatParamsV0},
+		{config, 0, GoatParamsV0},
+		{config, 99, GoatParamsV0},
+		{config, 100, v1},
+		{config, 199, v1},
+		{config, 200, v2},
+		{config, 1000, v2},
+	}
+	for i, tt := range tests {
+		if got := tt.config.Params(tt.time); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("test %d: params mismatch at %d: have %+v, want %+v", i, tt.time, got, tt.want)
+		}
+	}
+}
+
+func TestGoatConfigForkOrder(t *testing.T) {
+	valid := GoatParamsV0
+	tests := []struct {
+		forks   []*GoatFork
+		wantErr bool
+	}{
+		{nil, false},
+		{[]*GoatFork{{Time: 0, GoatParams: valid}, {Time: 10, GoatParams: valid}}, false},
+		{[]*GoatFork{{Time: 10, GoatParams: valid}, {Time: 10, GoatParams: valid}}, true},
+		{[]*GoatFork{{Time: 10, GoatParams: valid}, {Time: 5, GoatParams: valid}}, true},
+		{[]*GoatFork{nil}, true},
+		{[]*GoatFork{{GoatParams: GoatParams{TxLimitPerBlock: 256, TxGasLimit: 1, HeaderExtraLength: 33}}}, true},
+		{[]*GoatFork{{GoatParams: GoatParams{TxLimitPerBlock: 1, TxGasLimit: 0, HeaderExtraLength: 33}}}, true},
+		{[]*GoatFork{{GoatParams: GoatParams{TxLimitPerBlock: 1, TxGasLimit: 1, HeaderExtraLength: 32}}}, true},
+		{[]*GoatFork{{GoatParams: GoatParams{TxLimitPerBlock: 1, TxGasLimit: 1, HeaderExtraLength: 65}}}, true},
+		{[]*GoatFork{{GoatParams: GoatParams{TxLimitPerBlock: 1, TxGasLimit: 1, HeaderExtraLength: 33, FoundationTax: 10001}}}, true},
+		{[]*GoatFork{{GoatParams: GoatParams{TxLimitPerBlock: 1, TxGasLimit: 1, HeaderExtraLength: 46, HeaderExtraVersion: 1}}}, false},
+		{[]*GoatFork{{GoatParams: GoatParams{TxLimitPerBlock: 1, TxGasLimit: 1, HeaderExtraLength: 45, HeaderExtraVersion: 1}}}, true},
+		{[]*GoatFork{{GoatParams: GoatParams{TxLimitPerBlock: 1, TxGasLimit: 1, HeaderExtraLength: 64, HeaderExtraVersion: 2}}}, true},
+	}
+	for i, tt := range tests {
+		config := *AllGoatDebugChainConfig
+		config.Goat = &GoatConfig{Forks: tt.forks}
+		if err := config.CheckConfigForkOrder(); (err != nil) != tt.wantErr {
+			t.Errorf("test %d: wantErr %v, got %v", i, tt.wantErr, err)
+		}
+	}
+}
+
+func TestGoatConfigCompatible(t *testing.T) {
+	v1 := GoatParamsV0
+	v1.FoundationTax = 500
+
+	stored := &GoatConfig{Forks: []*GoatFork{{Time: 100, GoatParams: v1}}}
+	tests := []struct {
+		new     *GoatConfig
+		head    uint64
+		wantErr bool
+	}{
+		{stored, 1000, false},
+		{&GoatConfig{}, 99, false},
+		{&GoatConfig{}, 100, true},
+		{&GoatConfig{Forks: []*GoatFork{{Time: 200, GoatParams: v1}}}, 150, true},
+		{&GoatConfig{Forks: []*GoatFork{{Time: 200, GoatParams: v1}}}, 50, false},
+		{&GoatConfig{Forks: []*GoatFork{{Time: 100, GoatParams: GoatParamsV0}}}, 100, true},
+		{&GoatConfig{Forks: []*GoatFork{{Time: 100, GoatParams: v1}, {Time: 300, GoatParams: GoatParamsV0}}}, 200, false},
+		{&GoatConfig{Forks: []*GoatFork{{Time: 100, GoatParams: v1}, {Time: 150, GoatParams: GoatParamsV0}}}, 200, true},
+	}
+	for i, tt := range tests {
+		if err := stored.checkCompatible(tt.new, tt.head); (err != nil) != tt.wantErr {
+			t.Errorf("test %d: wantErr %v, got %v", i, tt.wantErr, err)
+		}
+	}
+}